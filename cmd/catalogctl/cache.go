@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	dbGenerated "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+// warmBatchSize bounds how many products are loaded per query while warming.
+const warmBatchSize = 500
+
+var (
+	// cacheFamilies are the cache namespaces used by the service.
+	cacheFamilies = []string{"product", "product_list", "seller_profile", "auth"}
+	// warmableFamilies can be rebuilt from Postgres alone; seller profiles and tokens come from the
+	// account service and fill on demand.
+	warmableFamilies = []string{"product", "product_list"}
+)
+
+func (a *app) cache(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing subcommand: flush or warm")
+	}
+
+	switch args[0] {
+	case "flush":
+		families, err := selectFamilies(args[1:], cacheFamilies)
+		if err != nil {
+			return err
+		}
+		return a.flushCaches(ctx, families)
+
+	case "warm":
+		families, err := selectFamilies(args[1:], warmableFamilies)
+		if err != nil {
+			return err
+		}
+		return a.warmCaches(ctx, families)
+
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+// ------- HELPERS -------
+
+func (a *app) flushCaches(ctx context.Context, families []string) error {
+	store, err := a.cacheStore()
+	if err != nil {
+		return err
+	}
+
+	for _, family := range families {
+		if err := store.InvalidateNamespace(ctx, family); err != nil {
+			return err
+		}
+		fmt.Printf("%s: flushed\n", family)
+	}
+	return nil
+}
+
+func (a *app) warmCaches(ctx context.Context, families []string) error {
+	client, err := a.redisClient()
+	if err != nil {
+		return err
+	}
+	// Loads would quietly fall through to Postgres and warm nothing.
+	if !client.Healthy() {
+		return errors.New("redis is unavailable")
+	}
+
+	store, err := a.cacheStore()
+	if err != nil {
+		return err
+	}
+	conn, err := a.db(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Warming only reads, so the write-path dependencies are left out.
+	productRepo := repositories.NewProductRepository(conn, dbGenerated.New(conn), a.log)
+	productService := services.NewProductService(productRepo, store, nil, nil, nil, nil, nil, nil, a.log)
+
+	products, err := productService.GetAllProducts(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to load products: %w", err)
+	}
+
+	for _, family := range families {
+		var warmed int
+		switch family {
+		case "product":
+			warmed, err = warmProducts(ctx, productService, products)
+		case "product_list":
+			warmed, err = warmProductLists(ctx, productService, products)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d entries warmed\n", family, warmed)
+	}
+	return nil
+}
+
+func warmProducts(ctx context.Context, productService services.ProductService, products []entities.Product) (int, error) {
+	ids := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	for batch := range slices.Chunk(ids, warmBatchSize) {
+		if _, err := productService.GetProductByIDs(ctx, batch); err != nil {
+			return 0, fmt.Errorf("failed to warm products: %w", err)
+		}
+	}
+	return len(ids), nil
+}
+
+// warmProductLists fills the storefront listing and every seller's product list. The listing
+// itself was loaded, and so cached, before this runs.
+func warmProductLists(ctx context.Context, productService services.ProductService, products []entities.Product) (int, error) {
+	sellers := make(map[uuid.UUID]struct{})
+	for _, product := range products {
+		sellers[product.SellerID] = struct{}{}
+	}
+
+	for sellerID := range sellers {
+		if _, err := productService.GetProductsBySellerID(ctx, sellerID); err != nil {
+			return 0, fmt.Errorf("failed to warm products of seller %s: %w", sellerID, err)
+		}
+	}
+	return 1 + len(sellers), nil
+}
+
+// selectFamilies validates the requested families against known; "all" selects every one.
+func selectFamilies(args, known []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("name at least one cache family, or all")
+	}
+	if len(args) == 1 && args[0] == "all" {
+		return known, nil
+	}
+
+	for _, family := range args {
+		if !slices.Contains(known, family) {
+			return nil, fmt.Errorf("unknown cache family %q, want one of %v or all", family, known)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,161 @@
+// Command catalogctl runs one-off operations against the catalog's database and caches: schema
+// migrations, development seed data, cache maintenance and aggregate rebuilds. It reads the same
+// settings as the web server.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logger"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+const usage = `Usage: catalogctl [-config file] [-set NAME=value]... <command> [arguments]
+
+Commands:
+  migrate up [N]            apply all pending migrations, or the next N
+  migrate down N | -all     roll back N migrations, or all of them
+  migrate to VERSION        migrate up or down to VERSION
+  migrate force VERSION     mark VERSION as applied and clean after a failed migration
+  migrate status            show the applied version and every known migration
+  seed [flags]              insert deterministic fake products and reviews (not in production)
+  cache flush FAMILY...     invalidate cache families: %s, or all
+  cache warm FAMILY...      preload cache families: %s, or all
+  rebuild aggregates        recompute product ratings and flush pending analytics counters
+`
+
+// app opens the database and Redis on first use, so each command connects only to what it needs.
+type app struct {
+	cfg *configs.AppConfig
+	log *logrus.Logger
+
+	conn  *sql.DB
+	redis *redis.RedisClient
+	store *cache.Store
+}
+
+func main() {
+	log := logger.NewLogger()
+	// stdout is reserved for command output.
+	log.SetOutput(os.Stderr)
+
+	var sources configs.Sources
+	flag.StringVar(&sources.File, "config", os.Getenv("CONFIG_FILE"), "YAML file of settings; the environment and -set take precedence")
+	flag.Var(&sources.Overrides, "set", "override one setting as NAME=value; may be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, strings.Join(cacheFamilies, ", "), strings.Join(warmableFamilies, ", "))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := configs.LoadConfig(log, sources)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.LogLevel != "" {
+		level, _ := logrus.ParseLevel(cfg.LogLevel)
+		log.SetLevel(level)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	a := &app{cfg: cfg, log: log}
+	defer a.close()
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "migrate":
+		err = a.migrate(ctx, args)
+	case "seed":
+		err = a.seed(ctx, args)
+	case "cache":
+		err = a.cache(ctx, args)
+	case "rebuild":
+		err = a.rebuild(ctx, args)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		a.close()
+		os.Exit(2)
+	}
+	if err != nil {
+		a.close()
+		log.Fatalf("catalogctl %s: %v", command, err)
+	}
+}
+
+// ------- HELPERS -------
+
+func (a *app) db(ctx context.Context) (*sql.DB, error) {
+	if a.conn != nil {
+		return a.conn, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.Database.ConnectTimeout)
+	defer cancel()
+
+	conn, err := db.Connect(ctx, &a.cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to DB: %w", err)
+	}
+	a.conn = conn
+	return conn, nil
+}
+
+func (a *app) redisClient() (*redis.RedisClient, error) {
+	if a.redis != nil {
+		return a.redis, nil
+	}
+
+	client, err := redis.NewRedisClient(&a.cfg.Redis, a.log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	a.redis = client
+	return client, nil
+}
+
+func (a *app) cacheStore() (*cache.Store, error) {
+	if a.store != nil {
+		return a.store, nil
+	}
+
+	client, err := a.redisClient()
+	if err != nil {
+		return nil, err
+	}
+	a.store = cache.NewStore(client, &a.cfg.Cache, a.log)
+	return a.store, nil
+}
+
+func (a *app) close() {
+	if a.redis != nil {
+		a.redis.Close()
+		a.redis = nil
+	}
+	if a.conn != nil {
+		a.conn.Close()
+		a.conn = nil
+	}
+}
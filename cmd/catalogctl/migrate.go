@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/sirupsen/logrus"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/db"
+)
+
+func (a *app) migrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing subcommand: up, down, to, force or status")
+	}
+
+	if args[0] == "status" {
+		return a.migrateStatus()
+	}
+
+	m, err := a.migrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		n, err := optionalCount(args[1:])
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			err = m.Up()
+		} else {
+			err = m.Steps(n)
+		}
+		return a.reportMigration(m, err)
+
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		all := flags.Bool("all", false, "roll back every migration")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *all {
+			return a.reportMigration(m, m.Down())
+		}
+		// Rolling everything back drops the catalog, so it is never the default.
+		n, err := optionalCount(flags.Args())
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.New("migrate down needs a step count, or -all to roll back everything")
+		}
+		return a.reportMigration(m, m.Steps(-n))
+
+	case "to":
+		version, err := versionArg(args[1:])
+		if err != nil {
+			return err
+		}
+		if version < 0 {
+			return errors.New("version must not be negative")
+		}
+		return a.reportMigration(m, m.Migrate(uint(version)))
+
+	case "force":
+		version, err := versionArg(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := m.Force(version); err != nil {
+			return fmt.Errorf("failed to force version %d: %w", version, err)
+		}
+		a.log.WithField("version", version).Warn("Migration version forced, the schema was not changed")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+// ------- HELPERS -------
+
+func (a *app) migrator(ctx context.Context) (*migrate.Migrate, error) {
+	m, err := migrate.New(a.cfg.Migration.Path, db.MigrationURL(&a.cfg.Database))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	m.Log = migrateLogger{log: a.log}
+
+	// Stop between migrations on Ctrl-C rather than abandoning one halfway.
+	go func() {
+		<-ctx.Done()
+		select {
+		case m.GracefulStop <- true:
+		default:
+		}
+	}()
+
+	return m, nil
+}
+
+func (a *app) reportMigration(m *migrate.Migrate, err error) error {
+	switch {
+	case errors.Is(err, migrate.ErrNoChange):
+		a.log.Info("No migrations to apply")
+	case err != nil:
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		fmt.Println("version: none")
+	case err != nil:
+		return fmt.Errorf("failed to read schema version: %w", err)
+	default:
+		fmt.Printf("version: %d dirty: %t\n", version, dirty)
+	}
+	return nil
+}
+
+func (a *app) migrateStatus() error {
+	m, err := migrate.New(a.cfg.Migration.Path, db.MigrationURL(&a.cfg.Database))
+	if err != nil {
+		return fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	defer m.Close()
+
+	current, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	applied := err == nil
+
+	src, err := source.Open(a.cfg.Migration.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open migrations: %w", err)
+	}
+	defer src.Close()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tNAME")
+
+	version, err := src.First()
+	for err == nil {
+		state := "pending"
+		switch {
+		case applied && version == current && dirty:
+			state = "dirty"
+		case applied && version <= current:
+			state = "applied"
+		}
+
+		name := ""
+		if r, identifier, err := src.ReadUp(version); err == nil {
+			r.Close()
+			name = identifier
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", version, state, name)
+
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	return w.Flush()
+}
+
+func optionalCount(args []string) (int, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid step count %q", args[0])
+	}
+	return n, nil
+}
+
+func versionArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New("expected exactly one VERSION")
+	}
+	version, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid version %q", args[0])
+	}
+	return version, nil
+}
+
+// migrateLogger reports each applied migration through logrus.
+type migrateLogger struct {
+	log *logrus.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...interface{}) {
+	l.log.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return l.log.IsLevelEnabled(logrus.DebugLevel)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dbGenerated "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+func (a *app) rebuild(ctx context.Context, args []string) error {
+	if len(args) != 1 || args[0] != "aggregates" {
+		return errors.New("expected: rebuild aggregates")
+	}
+
+	conn, err := a.db(ctx)
+	if err != nil {
+		return err
+	}
+	queries := dbGenerated.New(conn)
+	productRepo := repositories.NewProductRepository(conn, queries, a.log)
+
+	// Rating aggregates are adjusted incrementally per review; recomputing them repairs any drift.
+	rebuilt, err := productRepo.RebuildProductRatings(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("ratings: %d products corrected\n", rebuilt)
+
+	client, err := a.redisClient()
+	if err != nil {
+		return err
+	}
+	if !client.Healthy() {
+		return errors.New("redis is unavailable, analytics counters were not flushed")
+	}
+
+	// Daily stats lag Redis by one flush interval; flushing now brings them up to date.
+	analyticsService := services.NewAnalyticsService(
+		repositories.NewAnalyticsCounterRepository(client, a.cfg.Analytics.CounterTTL, a.log),
+		repositories.NewAnalyticsRepository(queries, a.log),
+		productRepo,
+		a.cfg.Analytics.MaxRangeDays,
+		a.log,
+	)
+	flushed, err := analyticsService.FlushCounters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to flush analytics counters: %w", err)
+	}
+	fmt.Printf("daily stats: %d product-days flushed\n", flushed)
+
+	if rebuilt > 0 {
+		if err := a.flushCaches(ctx, []string{"product", "product_list"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	dbGenerated "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+)
+
+// seedNamespace derives the seeded IDs, so the same -seed always produces the same rows and
+// running seed twice inserts nothing new.
+var seedNamespace = uuid.MustParse("6f1c7a52-3b0e-4d8f-9a61-2f8e5c4b7d10")
+
+// seedEpoch is the creation time of the first seeded product; later rows are spaced out from it.
+var seedEpoch = time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+var (
+	seedFranchises = []string{"Gundam", "Evangelion", "One Piece", "Dragon Ball", "Pokémon", "Zoids", "Macross", "Star Wars", "Transformers", "Hololive"}
+	seedEditions   = []string{"", "", "", "Limited Edition", "Deluxe", "Reissue", "Event Exclusive"}
+	seedKinds      = []struct {
+		productType string
+		label       string
+		// Price bounds are in thousands of rupiah.
+		minPrice, maxPrice int
+	}{
+		{"model_kit", "Model Kit", 150, 1500},
+		{"figure", "Scale Figure", 300, 3500},
+		{"trading_card", "Booster Box", 400, 2000},
+		{"diecast", "Die-cast", 100, 1200},
+		{"plush", "Plush", 80, 400},
+		{"board_game", "Board Game", 250, 1500},
+	}
+	seedReviewBodies = []string{
+		"Arrived well packed, exactly as pictured.",
+		"Great detail for the price.",
+		"Took a while to ship but worth the wait.",
+		"Paint has a few blemishes, otherwise fine.",
+		"My kid loves it.",
+		"",
+	}
+)
+
+func (a *app) seed(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	products := flags.Int("products", 60, "number of products to seed")
+	sellers := flags.Int("sellers", 5, "number of sellers the products are spread across")
+	reviews := flags.Int("reviews", 8, "maximum reviews per published product")
+	seed := flags.Uint64("seed", 1, "random seed; each seed produces its own dataset")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if a.cfg.Env == "production" {
+		return errors.New("refusing to seed fake data in production")
+	}
+	if *products < 0 || *sellers < 1 || *reviews < 0 {
+		return errors.New("-products and -reviews must not be negative and -sellers must be at least 1")
+	}
+
+	conn, err := a.db(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := dbGenerated.New(tx)
+	r := rand.New(rand.NewPCG(*seed, *seed))
+	reviewers := seedIDs(*seed, "reviewer", 20)
+	sellerIDs := seedIDs(*seed, "seller", *sellers)
+
+	var productsInserted, reviewsInserted int64
+	for i, productID := range seedIDs(*seed, "product", *products) {
+		product := fakeProduct(r, i, productID, sellerIDs[i%len(sellerIDs)])
+		inserted, err := q.SeedProduct(ctx, product)
+		if err != nil {
+			return fmt.Errorf("failed to seed product %s: %w", productID, err)
+		}
+		productsInserted += inserted
+
+		if product.Status != string(entities.ProductStatusPublished) {
+			continue
+		}
+
+		n := r.IntN(*reviews + 1)
+		for j, reviewer := range r.Perm(len(reviewers))[:min(n, len(reviewers))] {
+			review := fakeReview(r, *seed, product, j, reviewers[reviewer])
+			inserted, err := q.SeedReview(ctx, review)
+			if err != nil {
+				return fmt.Errorf("failed to seed review %s: %w", review.ID, err)
+			}
+			reviewsInserted += inserted
+		}
+	}
+
+	// Seeded reviews bypass the review service, so the rating aggregates are derived afterwards.
+	if _, err := q.RebuildProductRatings(ctx); err != nil {
+		return fmt.Errorf("failed to rebuild product ratings: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit seed data: %w", err)
+	}
+
+	fmt.Printf("products: %d inserted\nreviews: %d inserted\n", productsInserted, reviewsInserted)
+
+	if productsInserted > 0 || reviewsInserted > 0 {
+		if err := a.flushCaches(ctx, []string{"product", "product_list"}); err != nil {
+			a.log.WithError(err).Warn("Seeded data may be hidden by cached products until they expire")
+		}
+	}
+
+	return nil
+}
+
+// ------- HELPERS -------
+
+func seedIDs(seed uint64, kind string, n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.NewSHA1(seedNamespace, fmt.Appendf(nil, "%d/%s/%d", seed, kind, i))
+	}
+	return ids
+}
+
+func fakeProduct(r *rand.Rand, i int, id, sellerID uuid.UUID) dbGenerated.SeedProductParams {
+	franchise := seedFranchises[r.IntN(len(seedFranchises))]
+	kind := seedKinds[r.IntN(len(seedKinds))]
+	edition := seedEditions[r.IntN(len(seedEditions))]
+
+	var discount sql.NullInt32
+	if r.IntN(10) < 3 {
+		discount = sql.NullInt32{Int32: int32(5 * (1 + r.IntN(6))), Valid: true}
+	}
+
+	// Most of the catalog is live; a few products sit in each earlier moderation state.
+	status := entities.ProductStatusPublished
+	switch i % 10 {
+	case 0:
+		status = entities.ProductStatusPendingReview
+	case 1:
+		status = entities.ProductStatusDraft
+	}
+
+	return dbGenerated.SeedProductParams{
+		ID:          id,
+		SellerID:    sellerID,
+		Name:        strings.TrimSpace(fmt.Sprintf("%s %s %s", franchise, kind.label, edition)),
+		Price:       int32((kind.minPrice + r.IntN(kind.maxPrice-kind.minPrice+1)) * 1000),
+		Stock:       int32(r.IntN(50)),
+		Discount:    discount,
+		Type:        sql.NullString{String: kind.productType, Valid: true},
+		Description: sql.NullString{String: fmt.Sprintf("%s from the %s line. Seeded for development.", kind.label, franchise), Valid: true},
+		Status:      string(status),
+		CreatedAt:   seedEpoch.Add(time.Duration(i) * time.Hour),
+	}
+}
+
+func fakeReview(r *rand.Rand, seed uint64, product dbGenerated.SeedProductParams, j int, reviewer uuid.UUID) dbGenerated.SeedReviewParams {
+	body := seedReviewBodies[r.IntN(len(seedReviewBodies))]
+
+	return dbGenerated.SeedReviewParams{
+		ID:        uuid.NewSHA1(seedNamespace, fmt.Appendf(nil, "%d/review/%s/%s", seed, product.ID, reviewer)),
+		ProductID: product.ID,
+		UserID:    reviewer,
+		// The better of two draws skews ratings high, as real storefronts do.
+		Rating:           int16(max(1+r.IntN(5), 1+r.IntN(5))),
+		Body:             sql.NullString{String: body, Valid: body != ""},
+		VerifiedPurchase: r.IntN(2) == 0,
+		CreatedAt:        product.CreatedAt.Add(time.Duration(j+1) * 24 * time.Hour),
+	}
+}
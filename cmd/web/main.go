@@ -2,16 +2,34 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
-	"time"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"syscall"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcHealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -20,15 +38,30 @@ import (
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/db"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/crons"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/events"
 	customMiddleware "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/middlewares"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/problem"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/routes"
 	grpcServerImpl "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/grpc"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/handlers"
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/auth"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/background"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/consumer"
 	dbGenerated "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpcauth"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/health"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logger"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/messaging"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/ratelimit"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/tlsconfig"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/tracing"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
 
@@ -39,48 +72,61 @@ func main() {
 	log := logger.NewLogger()
 	log.Println("newlogger executed")
 
-	cfg, err := configs.LoadConfig(log)
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	var sources configs.Sources
+	flag.StringVar(&sources.File, "config", os.Getenv("CONFIG_FILE"), "YAML file of settings; the environment and -set take precedence")
+	flag.Var(&sources.Overrides, "set", "override one setting as NAME=value; may be repeated")
+	printConfig := flag.Bool("print-config", false, "print the effective settings with secrets redacted, then exit")
+	flag.Parse()
+
+	cfg, err := configs.LoadConfig(log, sources)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if cfg.LogLevel != "" {
+		level, _ := logrus.ParseLevel(cfg.LogLevel)
+		log.SetLevel(level)
+	}
 
-	dbCredential := models.Credential{
-		Host:         cfg.Database.Host,
-		Username:     cfg.Database.User,
-		Password:     cfg.Database.Password,
-		DatabaseName: cfg.Database.Name,
-		Port:         cfg.Database.Port,
+	if *printConfig {
+		settings := cfg.Dump()
+		for _, name := range slices.Sorted(maps.Keys(settings)) {
+			fmt.Printf("%s=%s\n", name, settings[name])
+		}
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	shutdownTracing, err := tracing.Init(context.Background(), &cfg.Tracing, log)
+	if err != nil {
+		log.Fatalf("Failed to initialise tracing: %v", err)
+	}
 
-	connectionString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		dbCredential.Username,
-		dbCredential.Password,
-		dbCredential.Host,
-		dbCredential.Port,
-		dbCredential.DatabaseName,
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Database.ConnectTimeout)
+	defer cancel()
 
-	conn, err := db.Connect(ctx, &dbCredential)
+	conn, err := db.Connect(ctx, &cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to DB: %v", err)
 	}
 
+	defer conn.Close()
+
 	// Migrations
-	m, err := migrate.New(
-		cfg.Migration.Path,
-		connectionString,
-	)
-	if err != nil {
-		log.Fatalf("Failed to create migrate instance: %v", err)
-	}
+	if cfg.Migration.AutoApply {
+		m, err := migrate.New(
+			cfg.Migration.Path,
+			db.MigrationURL(&cfg.Database),
+		)
+		if err != nil {
+			log.Fatalf("Failed to create migrate instance: %v", err)
+		}
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatalf("Failed to run migrations: %v", err)
+		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
 	}
-	defer conn.Close()
 
 	// Init SQLC query
 	sqlcQueries := dbGenerated.New(conn)
@@ -92,57 +138,356 @@ func main() {
 	}
 	defer redisClient.Close()
 
-	accountClientGateway, err := account.NewAccountClient(cfg.GRPC.AccountServiceAddress)
+	cacheStore := cache.NewStore(redisClient, &cfg.Cache, log)
+
+	if err := metrics.RegisterDB(conn, cfg.Database.Name); err != nil {
+		log.Warnf("Failed to register DB pool metrics: %v", err)
+	}
+	if err := metrics.RegisterRedis(redisClient.Client); err != nil {
+		log.Warnf("Failed to register Redis pool metrics: %v", err)
+	}
+
+	// TLS files are reloaded in the background once the task tracker is up.
+	var tlsSources []*tlsconfig.Source
+
+	accountCreds := insecure.NewCredentials()
+	if !cfg.GRPC.Insecure {
+		accountTLS, err := tlsconfig.NewSource(tlsconfig.Files{CertFile: cfg.GRPC.CertFile, KeyFile: cfg.GRPC.KeyFile, CAFile: cfg.GRPC.CAFile}, log)
+		if err != nil {
+			log.Fatalf("Failed to load account service TLS files: %v", err)
+		}
+		tlsSources = append(tlsSources, accountTLS)
+		accountCreds = accountTLS.ClientCredentials(cfg.GRPC.ServerName)
+	}
+
+	accountClientGateway, err := account.NewAccountClient(&cfg.GRPC, accountCreds, log)
 	if err != nil {
 		log.Fatalf("Failed to create account client: %v", err)
 	}
 	defer accountClientGateway.Close()
 
-	authClientGateway, err := account.NewAuthClient(cfg.GRPC.AccountServiceAddress)
+	authClientGateway, err := account.NewAuthClient(cfg.GRPC.AccountServiceAddress, accountCreds)
 	if err != nil {
 		log.Fatalf("Failed to create auth client: %v", err)
 	}
 	defer authClientGateway.Close()
 
+	// RabbitMQ
+	amqpConn, err := amqp.Dial(cfg.RabbitMQ.URL)
+	if err != nil {
+		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
+	}
+	defer amqpConn.Close()
+
+	messagingManager, err := messaging.NewManager(amqpConn, cfg.RabbitMQ.BufferSize, log)
+	if err != nil {
+		log.Fatalf("Failed to create messaging manager: %v", err)
+	}
+
+	// Background work is cancelled only after traffic has drained, then waited for.
+	bgCtx, cancelBackground := context.WithCancel(context.Background())
+	defer cancelBackground()
+	tasks := background.NewTracker(log)
+
 	productsRepo := repositories.NewProductRepository(conn, sqlcQueries, log)
-	cartsRepo := repositories.NewCartRepository(redisClient, log)
+	cartsRepo := repositories.NewCartRepository(redisClient, sqlcQueries, log)
+	reviewsRepo := repositories.NewReviewRepository(conn, sqlcQueries, log)
+	questionsRepo := repositories.NewQuestionRepository(sqlcQueries, log)
+	analyticsRepo := repositories.NewAnalyticsRepository(sqlcQueries, log)
+	analyticsCounterRepo := repositories.NewAnalyticsCounterRepository(redisClient, cfg.Analytics.CounterTTL, log)
 	validate := validator.New()
+	// Report JSON field names in validation errors, since that is what clients send.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
 
-	productService := services.NewProductService(productsRepo, redisClient, validate, log)
-	cartService := services.NewCartService(cartsRepo, productService, redisClient, accountClientGateway, log)
+	keywordScreener := services.NewKeywordScreener(cfg.Moderation.BannedKeywords)
+
+	policy, err := authz.NewPolicy(cfg.Auth.RolePermissions)
+	if err != nil {
+		log.Fatalf("Failed to load role permissions: %v", err)
+	}
 
-	productHandler := handlers.NewProductHandler(productService, log)
+	sellerDirectory := services.NewSellerDirectory(cacheStore, accountClientGateway, cfg.Storefront.ProfileCacheTTL, log)
+	analyticsService := services.NewAnalyticsService(analyticsCounterRepo, analyticsRepo, productsRepo, cfg.Analytics.MaxRangeDays, log)
+	productService := services.NewProductService(productsRepo, cacheStore, validate, keywordScreener, messagingManager, analyticsService, tasks, policy, log)
+	cartService := services.NewCartService(cartsRepo, productService, redisClient, sellerDirectory, analyticsService, log)
+	moderationService := services.NewModerationService(productsRepo, productService, keywordScreener, messagingManager, cfg.Moderation.RequireReview, validate, policy, log)
+	reviewService := services.NewReviewService(reviewsRepo, productsRepo, productService, cfg.Review.ReportHideThreshold, validate, policy, log)
+	questionService := services.NewQuestionService(questionsRepo, productsRepo, productService, keywordScreener, messagingManager, validate, policy, log)
+	storefrontService := services.NewStorefrontService(productsRepo, sellerDirectory, cfg.Storefront.FeaturedLimit, cfg.Storefront.NewArrivalsLimit, log)
+
+	productHandler := handlers.NewProductHandler(productService, analyticsService, log)
 	cartHandler := handlers.NewCartHandler(cartService, log)
+	moderationHandler := handlers.NewModerationHandler(moderationService, log)
+	reviewHandler := handlers.NewReviewHandler(reviewService, log)
+	questionHandler := handlers.NewQuestionHandler(questionService, log)
+	storefrontHandler := handlers.NewStorefrontHandler(storefrontService, log)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, log)
+
+	// Health: Postgres is the only hard dependency; everything else degrades.
+	healthChecker := health.NewChecker(cfg.Health.CheckTimeout)
+	healthChecker.Register("postgres", health.Critical, conn.PingContext)
+	healthChecker.Register("redis", health.Degraded, func(ctx context.Context) error {
+		return redisClient.Client.Ping(ctx).Err()
+	})
+	healthChecker.Register("account_grpc", health.Degraded, accountClientGateway.CheckConnection)
+	healthChecker.Register("rabbitmq", health.Degraded, func(ctx context.Context) error {
+		if amqpConn.IsClosed() {
+			return fmt.Errorf("rabbitmq connection is closed")
+		}
+		return nil
+	})
+	healthHandler := handlers.NewHealthHandler(healthChecker, log)
+	configHandler := handlers.NewConfigHandler(cfg.Dump())
+
+	// Auth: local and hybrid modes verify signatures against the account service's JWKS.
+	var jwks *auth.KeySet
+	if cfg.Auth.Mode != auth.ModeRemote {
+		jwks, err = auth.NewKeySet(&cfg.Auth, log)
+		if err != nil {
+			log.Fatalf("Failed to create JWKS key set: %v", err)
+		}
+		tasks.Go("jwks_refresh", func() { jwks.Run(bgCtx) })
+		healthChecker.Register("jwks", health.Degraded, jwks.Check)
+	}
 
-	authMiddleware := customMiddleware.AuthMiddleware(authClientGateway, cfg.Server.JWTSecret, cfg.Server.Audience, log)
+	tokenVerifier, err := auth.NewVerifier(&cfg.Auth, jwks, authClientGateway, cacheStore, redisClient, log)
+	if err != nil {
+		log.Fatalf("Failed to create token verifier: %v", err)
+	}
+	authMiddleware := customMiddleware.AuthMiddleware(tokenVerifier)
 
 	lis, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen for gRPC server: %v", err)
 	}
-	s := grpc.NewServer()
+	grpcAuth, err := grpcauth.New(&cfg.GRPCServer, log)
+	if err != nil {
+		log.Fatalf("Failed to configure gRPC authentication: %v", err)
+	}
+	limiter, err := ratelimit.New(&cfg.RateLimit, redisClient, log)
+	if err != nil {
+		log.Fatalf("Failed to configure rate limits: %v", err)
+	}
+	grpcOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logctx.UnaryServerInterceptor(), metrics.UnaryServerInterceptor(), grpcAuth.UnaryServerInterceptor(), limiter.UnaryServerInterceptor("grpc")),
+		grpc.ChainStreamInterceptor(logctx.StreamServerInterceptor(), metrics.StreamServerInterceptor(), grpcAuth.StreamServerInterceptor(), limiter.StreamServerInterceptor("grpc")),
+	}
+	var httpTLS *tls.Config
+	if cfg.Server.Insecure {
+		log.Warn("Serving HTTP and gRPC without TLS, service tokens are sent in plaintext")
+	} else {
+		serverTLS, err := tlsconfig.NewSource(tlsconfig.Files{CertFile: cfg.Server.TLSCertFile, KeyFile: cfg.Server.TLSKeyFile, CAFile: cfg.Server.TLSClientCAFile}, log)
+		if err != nil {
+			log.Fatalf("Failed to load server TLS files: %v", err)
+		}
+		tlsSources = append(tlsSources, serverTLS)
+
+		grpcTLS, err := serverTLS.ServerConfig(false, "h2")
+		if err != nil {
+			log.Fatalf("Failed to configure gRPC TLS: %v", err)
+		}
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(grpcTLS)))
+
+		httpTLS, err = serverTLS.ServerConfig(cfg.Server.HTTPRequireClientCert, "h2", "http/1.1")
+		if err != nil {
+			log.Fatalf("Failed to configure HTTP TLS: %v", err)
+		}
+	}
+	s := grpc.NewServer(grpcOpts...)
 
-	productServer := grpcServerImpl.NewProductServer(productService)
+	productServer := grpcServerImpl.NewProductServer(productService, log)
 	productpb.RegisterProductServiceServer(s, productServer)
-	reflection.Register(s)
+	if cfg.Env != "production" {
+		reflection.Register(s)
+	}
+
+	grpcHealthServer := grpcHealth.NewServer()
+	healthpb.RegisterHealthServer(s, grpcHealthServer)
+	tasks.Go("grpc_health_sync", func() {
+		healthChecker.SyncGRPC(bgCtx, grpcHealthServer, cfg.Health.GRPCSyncInterval, productpb.ProductService_ServiceDesc.ServiceName)
+	})
 
+	// Order events (verified purchases)
+	orderConsumer, err := consumer.NewRabbitMQConsumer(amqpConn, cfg.RabbitMQ.OrderExchange, cfg.RabbitMQ.OrderRoutingKey, cfg.RabbitMQ.OrderQueue, log)
+	if err != nil {
+		log.Fatalf("Failed to create order event consumer: %v", err)
+	}
+	defer orderConsumer.Close()
+
+	orderEventHandler := events.NewOrderEventHandler(reviewService, log)
+	consumerDone := make(chan struct{})
 	go func() {
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve gRPC server: %v", err)
+		defer close(consumerDone)
+		if err := orderConsumer.Consume(orderEventHandler.HandleOrderCreated); err != nil {
+			log.Errorf("Order event consumer stopped: %v", err)
 		}
 	}()
 
+	// Analytics counters (Redis -> Postgres)
+	analyticsFlushJob := crons.NewAnalyticsFlushJob(analyticsService, cfg.Analytics.FlushInterval, log)
+	tasks.Go("analytics_flush", func() { analyticsFlushJob.Run(bgCtx) })
+	tasks.Go("cache_invalidation_listener", func() { cacheStore.ListenForInvalidations(bgCtx) })
+	for _, source := range tlsSources {
+		tasks.Go("tls_reload", func() { source.Run(bgCtx, cfg.Server.TLSReloadInterval) })
+	}
+
 	// Setup Echo (REST API)
+	// Rate limits key anonymous callers by IP, so X-Forwarded-For is only believed from proxies.
+	var trustedProxies []echo.TrustOption
+	for _, cidr := range cfg.RateLimit.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			log.Fatalf("Invalid trusted proxy range %q: %v", cidr, err)
+		}
+		trustedProxies = append(trustedProxies, echo.TrustIPRange(ipNet))
+	}
+
+	bodyLimit, err := customMiddleware.BodyLimit(cfg.HTTP.BodyLimit, cfg.HTTP.BodyLimitRoutes)
+	if err != nil {
+		log.Fatalf("Failed to configure body limits: %v", err)
+	}
+
 	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.IPExtractor = echo.ExtractIPFromXFFHeader(trustedProxies...)
 	e.Use(middleware.RequestID())
+	e.Use(problem.Middleware(cfg.Server.LegacyErrorResponses))
+	e.Use(otelecho.Middleware(cfg.Tracing.ServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		// Probes would drown out real traffic.
+		switch c.Path() {
+		case "/healthz", "/readyz":
+			return true
+		}
+		return false
+	})))
+	e.Use(customMiddleware.MetricsMiddleware())
 	e.Use(customMiddleware.LoggingMiddleware(log))
+	if cfg.HTTP.CompressionEnabled {
+		e.Use(customMiddleware.Compress(cfg.HTTP.CompressionMinLength))
+	}
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"}, // Nginx will handle stricter CORS
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowOrigins:     cfg.HTTP.CORSAllowOrigins,
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		ExposeHeaders:    []string{echo.HeaderXRequestID, echo.HeaderRetryAfter, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"},
+		AllowCredentials: cfg.HTTP.CORSAllowCredentials,
+		MaxAge:           int(cfg.HTTP.CORSMaxAge.Seconds()),
 	}))
+	e.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         cfg.HTTP.FrameOptions,
+		HSTSMaxAge:            int(cfg.HTTP.HSTSMaxAge.Seconds()),
+		HSTSExcludeSubdomains: !cfg.HTTP.HSTSIncludeSubdomains,
+		// The API only serves JSON, so nothing may be loaded or framed from its responses.
+		ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+		ReferrerPolicy:        "no-referrer",
+	}))
+	e.Use(bodyLimit)
+	e.Use(middleware.ContextTimeout(cfg.HTTP.RequestTimeout))
+
+	routes.InitRoutes(e, productHandler, cartHandler, moderationHandler, reviewHandler, questionHandler, storefrontHandler, analyticsHandler, healthHandler, configHandler, authMiddleware, policy, limiter)
 
-	routes.InitRoutes(e, productHandler, cartHandler, authMiddleware)
+	// Metrics get their own listener so scrapes never share the public port.
+	metricsServer := &http.Server{
+		Addr:              ":" + cfg.Server.MetricsPort,
+		Handler:           promhttp.Handler(),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+	}
+
+	serverErr := make(chan error, 3)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			serverErr <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+	for _, server := range []*http.Server{e.Server, e.TLSServer} {
+		server.ReadHeaderTimeout = cfg.HTTP.ReadHeaderTimeout
+		server.ReadTimeout = cfg.HTTP.ReadTimeout
+		server.IdleTimeout = cfg.HTTP.IdleTimeout
+	}
+	go func() {
+		var err error
+		if httpTLS != nil {
+			e.TLSServer.Addr = ":" + cfg.Server.Port
+			e.TLSServer.TLSConfig = httpTLS
+			err = e.StartServer(e.TLSServer)
+		} else {
+			err = e.Start(":" + cfg.Server.Port)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- fmt.Errorf("metrics server: %w", err)
+		}
+	}()
+
+	select {
+	case <-signalCtx.Done():
+		log.Info("Shutdown signal received")
+	case err := <-serverErr:
+		log.Errorf("Server failed, shutting down: %v", err)
+	}
+
+	// Shutdown order: stop taking traffic, drain in-flight requests, stop consuming, finish
+	// background work, flush outgoing events, stop serving metrics. Deferred closes then release
+	// DB, Redis and clients.
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelShutdown()
+
+	healthChecker.MarkShuttingDown()
+	grpcHealthServer.Shutdown()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("HTTP server did not drain in time: %v", err)
+	}
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		log.Warn("gRPC server did not drain in time, forcing stop")
+		s.Stop()
+	}
+
+	if err := orderConsumer.Stop(); err != nil {
+		log.Warnf("Failed to stop order event consumer: %v", err)
+	}
+	select {
+	case <-consumerDone:
+	case <-shutdownCtx.Done():
+		log.Warn("Order event consumer did not finish in time")
+	}
+
+	cancelBackground()
+	if err := tasks.Wait(shutdownCtx); err != nil {
+		log.Warnf("Background tasks did not finish in time: %v", err)
+	}
+
+	if err := messagingManager.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("Messaging buffer was not fully flushed: %v", err)
+	}
+
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("Metrics server did not stop in time: %v", err)
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Warnf("Failed to flush traces: %v", err)
+	}
 
-	e.Logger.Fatal(e.Start(":" + cfg.Server.Port))
+	log.Info("Shutdown complete")
 }
@@ -1 +1 @@
-DROP TABLE products
\ No newline at end of file
+DROP TABLE IF EXISTS products;
@@ -10,4 +10,4 @@ CREATE TABLE products (
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
     deleted_at TIMESTAMP
-);
\ No newline at end of file
+);
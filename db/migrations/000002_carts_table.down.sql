@@ -1 +1 @@
-DROP TABLE carts
\ No newline at end of file
+DROP TABLE IF EXISTS carts;
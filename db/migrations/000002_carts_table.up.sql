@@ -6,4 +6,4 @@ CREATE TABLE carts (
     "description" TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-);
\ No newline at end of file
+);
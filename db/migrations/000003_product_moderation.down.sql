@@ -0,0 +1,6 @@
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_status_check,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+ALTER TABLE products
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'published',
+    ADD COLUMN status_reason TEXT,
+    ADD COLUMN reviewed_by UUID,
+    ADD COLUMN reviewed_at TIMESTAMP;
+
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'pending_review', 'published', 'suspended', 'archived'));
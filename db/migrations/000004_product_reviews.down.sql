@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS verified_purchases;
+DROP TABLE IF EXISTS review_reports;
+DROP TABLE IF EXISTS product_reviews;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_avg,
+    DROP COLUMN IF EXISTS rating_sum,
+    DROP COLUMN IF EXISTS rating_count;
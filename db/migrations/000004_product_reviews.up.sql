@@ -0,0 +1,38 @@
+ALTER TABLE products
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN rating_sum INT NOT NULL DEFAULT 0,
+    ADD COLUMN rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE TABLE product_reviews (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT,
+    verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    seller_reply TEXT,
+    seller_replied_at TIMESTAMP,
+    report_count INT NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (product_id, user_id)
+);
+
+CREATE INDEX idx_product_reviews_product_id ON product_reviews (product_id, created_at DESC);
+
+CREATE TABLE review_reports (
+    review_id UUID NOT NULL REFERENCES product_reviews (id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (review_id, reporter_id)
+);
+
+CREATE TABLE verified_purchases (
+    user_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    order_id TEXT NOT NULL,
+    purchased_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, product_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_answers;
+DROP TABLE IF EXISTS product_questions;
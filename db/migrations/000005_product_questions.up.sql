@@ -0,0 +1,24 @@
+CREATE TABLE product_questions (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    asker_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'flagged', 'hidden')),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_product_questions_product_id ON product_questions (product_id, created_at DESC);
+
+CREATE TABLE product_answers (
+    id UUID PRIMARY KEY,
+    question_id UUID NOT NULL REFERENCES product_questions (id) ON DELETE CASCADE,
+    responder_id UUID NOT NULL,
+    responder_role TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'visible' CHECK (status IN ('visible', 'flagged', 'hidden')),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_product_answers_question_id ON product_answers (question_id, created_at);
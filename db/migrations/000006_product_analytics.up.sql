@@ -0,0 +1,14 @@
+CREATE TABLE product_daily_stats (
+    product_id UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    seller_id UUID NOT NULL,
+    stat_date DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    unique_viewers INT NOT NULL DEFAULT 0,
+    add_to_carts INT NOT NULL DEFAULT 0,
+    orders INT NOT NULL DEFAULT 0,
+    units_sold INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_id, stat_date)
+);
+
+CREATE INDEX idx_product_daily_stats_seller_date ON product_daily_stats (seller_id, stat_date);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_carts_user_product;
+
+ALTER TABLE carts DROP COLUMN IF EXISTS checked;
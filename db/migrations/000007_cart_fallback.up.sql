@@ -0,0 +1,10 @@
+-- carts becomes the durable mirror of the Redis carts, read when Redis is unavailable.
+ALTER TABLE carts ADD COLUMN checked BOOLEAN NOT NULL DEFAULT TRUE;
+
+DELETE FROM carts a
+USING carts b
+WHERE a.user_id = b.user_id
+  AND a.product_id = b.product_id
+  AND (a.updated_at, a.ctid) < (b.updated_at, b.ctid);
+
+CREATE UNIQUE INDEX uq_carts_user_product ON carts (user_id, product_id);
@@ -0,0 +1,8 @@
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_discount_check,
+    DROP CONSTRAINT IF EXISTS products_stock_check,
+    DROP CONSTRAINT IF EXISTS products_price_check;
+
+DROP INDEX IF EXISTS idx_products_status;
+DROP INDEX IF EXISTS idx_products_type;
+DROP INDEX IF EXISTS idx_products_seller_id;
@@ -0,0 +1,16 @@
+-- Every product read filters on deleted_at IS NULL, so the indexes are partial on it rather than
+-- indexing the column itself.
+CREATE INDEX IF NOT EXISTS idx_products_seller_id ON products (seller_id, status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_type ON products ("type", status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_status ON products (status, updated_at) WHERE deleted_at IS NULL;
+
+-- Negative stock and out-of-range discounts can only be left over from bugs, so they are clamped
+-- for the constraints to hold. A price that is not positive has no safe default and fails the
+-- migration instead.
+UPDATE products SET stock = 0 WHERE stock < 0;
+UPDATE products SET discount = LEAST(GREATEST(discount, 0), 100) WHERE discount NOT BETWEEN 0 AND 100;
+
+ALTER TABLE products
+    ADD CONSTRAINT products_price_check CHECK (price > 0),
+    ADD CONSTRAINT products_stock_check CHECK (stock >= 0),
+    ADD CONSTRAINT products_discount_check CHECK (discount BETWEEN 0 AND 100);
@@ -0,0 +1,27 @@
+ALTER TABLE products
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP,
+    ALTER COLUMN deleted_at TYPE TIMESTAMP,
+    ALTER COLUMN reviewed_at TYPE TIMESTAMP;
+
+ALTER TABLE product_reviews
+    ALTER COLUMN seller_replied_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE review_reports
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE verified_purchases
+    ALTER COLUMN purchased_at TYPE TIMESTAMP;
+
+ALTER TABLE product_questions
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE product_answers
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE product_daily_stats
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
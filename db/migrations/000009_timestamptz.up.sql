@@ -0,0 +1,29 @@
+-- Naive timestamps are read in the session time zone, which MigrationURL sets to DB_TIMEZONE: the
+-- zone the service's NOW() wrote them in. Each ALTER rewrites its table under an exclusive lock.
+ALTER TABLE products
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN deleted_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN reviewed_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE product_reviews
+    ALTER COLUMN seller_replied_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE review_reports
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE verified_purchases
+    ALTER COLUMN purchased_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE product_questions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE product_answers
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE product_daily_stats
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
@@ -4,23 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func Connect(ctx context.Context, credential *models.Credential) (*sql.DB, error) {
+func Connect(ctx context.Context, cfg *configs.DatabaseConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Jakarta",
-		credential.Host,
-		credential.Username,
-		credential.Password,
-		credential.DatabaseName,
-		credential.Port,
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		quote(cfg.Host),
+		quote(cfg.User),
+		quote(cfg.Password),
+		quote(cfg.Name),
+		cfg.Port,
+		quote(cfg.SslMode),
+		quote(cfg.TimeZone),
 	)
 
-	db, err := sql.Open("postgres", dsn)
+	// otelsql wraps the driver so every sqlc query, including those inside transactions, gets a span.
+	db, err := otelsql.Open("postgres", dsn,
+		otelsql.WithAttributes(attribute.String("db.system", "postgresql")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open connection: %w", err)
 	}
@@ -29,9 +40,31 @@ func Connect(ctx context.Context, credential *models.Credential) (*sql.DB, error
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(100)
-	db.SetConnMaxLifetime(time.Hour)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	return db, nil
 }
+
+// MigrationURL returns the postgres:// URL golang-migrate expects, with credentials escaped. The
+// session uses the service's time zone, so migrations interpret naive timestamps as it wrote them.
+func MigrationURL(cfg *configs.DatabaseConfig) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     "/" + cfg.Name,
+		RawQuery: url.Values{"sslmode": {cfg.SslMode}, "TimeZone": {cfg.TimeZone}}.Encode(),
+	}
+	return u.String()
+}
+
+// ------- HELPERS -------
+
+// quote makes a value safe inside a key=value connection string, where spaces and quotes in a
+// password would otherwise split it.
+func quote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,75 @@
+-- name: UpsertProductDailyStats :execrows
+-- Counters are flushed as absolute daily values, so re-flushing the same day is idempotent.
+INSERT INTO product_daily_stats (
+  product_id,
+  seller_id,
+  stat_date,
+  views,
+  unique_viewers,
+  add_to_carts,
+  orders,
+  units_sold,
+  updated_at
+)
+SELECT
+  p.id,
+  p.seller_id,
+  sqlc.arg(stat_date)::date,
+  sqlc.arg(views)::int,
+  sqlc.arg(unique_viewers)::int,
+  sqlc.arg(add_to_carts)::int,
+  sqlc.arg(orders)::int,
+  sqlc.arg(units_sold)::int,
+  NOW()
+FROM products p
+WHERE p.id = sqlc.arg(product_id)
+ON CONFLICT (product_id, stat_date) DO UPDATE SET
+  views = GREATEST(product_daily_stats.views, EXCLUDED.views),
+  unique_viewers = GREATEST(product_daily_stats.unique_viewers, EXCLUDED.unique_viewers),
+  add_to_carts = GREATEST(product_daily_stats.add_to_carts, EXCLUDED.add_to_carts),
+  orders = GREATEST(product_daily_stats.orders, EXCLUDED.orders),
+  units_sold = GREATEST(product_daily_stats.units_sold, EXCLUDED.units_sold),
+  updated_at = NOW();
+
+-- name: GetSellerDailyStats :many
+SELECT
+  stat_date,
+  SUM(views)::int AS views,
+  SUM(unique_viewers)::int AS unique_viewers,
+  SUM(add_to_carts)::int AS add_to_carts,
+  SUM(orders)::int AS orders,
+  SUM(units_sold)::int AS units_sold
+FROM product_daily_stats
+WHERE seller_id = sqlc.arg(seller_id)
+  AND stat_date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+GROUP BY stat_date
+ORDER BY stat_date;
+
+-- name: GetSellerProductTotals :many
+SELECT
+  s.product_id,
+  p."name",
+  SUM(s.views)::int AS views,
+  SUM(s.unique_viewers)::int AS unique_viewers,
+  SUM(s.add_to_carts)::int AS add_to_carts,
+  SUM(s.orders)::int AS orders,
+  SUM(s.units_sold)::int AS units_sold
+FROM product_daily_stats s
+JOIN products p ON p.id = s.product_id
+WHERE s.seller_id = sqlc.arg(seller_id)
+  AND s.stat_date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+GROUP BY s.product_id, p."name"
+ORDER BY views DESC;
+
+-- name: GetProductDailyStats :many
+SELECT
+  stat_date,
+  views,
+  unique_viewers,
+  add_to_carts,
+  orders,
+  units_sold
+FROM product_daily_stats
+WHERE product_id = sqlc.arg(product_id)
+  AND stat_date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+ORDER BY stat_date;
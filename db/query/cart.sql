@@ -0,0 +1,27 @@
+-- name: UpsertCartItem :exec
+INSERT INTO carts (
+  id,
+  user_id,
+  product_id,
+  quantity,
+  "description",
+  checked,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (user_id, product_id) DO UPDATE SET
+  quantity = EXCLUDED.quantity,
+  "description" = EXCLUDED."description",
+  checked = EXCLUDED.checked,
+  updated_at = EXCLUDED.updated_at;
+
+-- name: GetCartItemsByUserID :many
+SELECT * FROM carts
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: DeleteCartItem :exec
+DELETE FROM carts
+WHERE user_id = $1 AND product_id = $2;
@@ -8,10 +8,11 @@ INSERT INTO products (
   discount, 
   "type", 
   "description", 
+  status,
   created_at, 
   updated_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()
 ) RETURNING *;
 
 -- name: GetAllProducts :many
@@ -24,10 +25,14 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE deleted_at IS NULL;
+WHERE deleted_at IS NULL AND status = 'published';
 
 -- name: GetProductByID :one
 SELECT 
@@ -39,6 +44,10 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
@@ -54,10 +63,14 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL;
+WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL AND status = 'published';
 
 -- name: GetProductsBySellerID :many
 SELECT 
@@ -69,10 +82,57 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE seller_id = $1 AND deleted_at IS NULL;
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published';
+
+-- name: GetProductsBySellerIDPaged :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE seller_id = sqlc.arg(seller_id) AND deleted_at IS NULL AND status = 'published'
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'rating' THEN rating_avg END DESC,
+  CASE WHEN sqlc.arg(sort)::text = 'rating' THEN rating_count END DESC,
+  CASE WHEN sqlc.arg(sort)::text = 'price_asc' THEN price END ASC,
+  CASE WHEN sqlc.arg(sort)::text = 'price_desc' THEN price END DESC,
+  created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: GetSellerProductStats :one
+SELECT
+  COUNT(*)::int AS product_count,
+  COALESCE(SUM(rating_count), 0)::int AS rating_count,
+  COALESCE(SUM(rating_sum), 0)::int AS rating_sum
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published';
+
+-- name: GetSellerCategories :many
+SELECT
+  "type"::text AS "type",
+  COUNT(*)::int AS product_count
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published' AND "type" IS NOT NULL AND "type" <> ''
+GROUP BY "type"
+ORDER BY product_count DESC, "type";
 
 -- name: GetProductsByName :many
 SELECT 
@@ -84,10 +144,14 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE name ILIKE $1 AND deleted_at IS NULL;
+WHERE name ILIKE $1 AND deleted_at IS NULL AND status = 'published';
 
 -- name: GetProductsByType :many
 SELECT 
@@ -99,10 +163,60 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE "type" = $1 AND deleted_at IS NULL AND status = 'published';
+
+-- name: GetProductsBySellerIDAnyStatus :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL
+ORDER BY updated_at DESC;
+
+-- name: GetProductsByStatus :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE "type" = $1 AND deleted_at IS NULL;
+WHERE status = $1 AND deleted_at IS NULL
+ORDER BY updated_at ASC;
+
+-- name: UpdateProductStatus :one
+UPDATE products
+SET status = $2, status_reason = $3, reviewed_by = $4, reviewed_at = $5, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
 
 -- name: UpdateProduct :one
 UPDATE products
@@ -121,6 +235,19 @@ SELECT stock FROM products WHERE id = $1;
 UPDATE products SET stock = $2 WHERE id = $1 
 RETURNING *;
 
+-- name: AdjustProductRating :one
+UPDATE products
+SET
+    rating_count = rating_count + sqlc.arg(count_delta),
+    rating_sum = rating_sum + sqlc.arg(sum_delta),
+    rating_avg = CASE
+        WHEN rating_count + sqlc.arg(count_delta) > 0
+        THEN (rating_sum + sqlc.arg(sum_delta))::float8 / (rating_count + sqlc.arg(count_delta))
+        ELSE 0
+    END
+WHERE id = sqlc.arg(product_id)
+RETURNING *;
+
 -- name: DecreaseProductStock :one
 UPDATE products
 SET
@@ -137,3 +264,26 @@ SET
 WHERE
     id = sqlc.arg(product_id)
 RETURNING *;
+
+-- name: RebuildProductRatings :execrows
+-- Recomputes the rating aggregates from visible product_reviews, touching only rows that drifted.
+UPDATE products p
+SET
+    rating_count = agg.rating_count,
+    rating_sum = agg.rating_sum,
+    rating_avg = CASE
+        WHEN agg.rating_count > 0
+        THEN agg.rating_sum::float8 / agg.rating_count
+        ELSE 0
+    END
+FROM (
+    SELECT
+        pr.id,
+        COUNT(r.id)::int AS rating_count,
+        COALESCE(SUM(r.rating), 0)::int AS rating_sum
+    FROM products pr
+    LEFT JOIN product_reviews r ON r.product_id = pr.id AND r.hidden = FALSE
+    GROUP BY pr.id
+) agg
+WHERE p.id = agg.id
+  AND (p.rating_count <> agg.rating_count OR p.rating_sum <> agg.rating_sum);
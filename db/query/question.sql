@@ -0,0 +1,73 @@
+-- name: InsertQuestion :one
+INSERT INTO product_questions (
+  id,
+  product_id,
+  asker_id,
+  body,
+  status,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, NOW(), NOW()
+) RETURNING *;
+
+-- name: GetQuestionByID :one
+SELECT * FROM product_questions
+WHERE id = $1;
+
+-- name: GetQuestionsByProductID :many
+SELECT * FROM product_questions
+WHERE product_id = sqlc.arg(product_id) AND status = 'visible'
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountQuestionsByProductID :one
+SELECT COUNT(*) FROM product_questions
+WHERE product_id = $1 AND status = 'visible';
+
+-- name: GetQuestionsByStatus :many
+SELECT * FROM product_questions
+WHERE status = sqlc.arg(status)
+ORDER BY created_at ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountQuestionsByStatus :one
+SELECT COUNT(*) FROM product_questions
+WHERE status = $1;
+
+-- name: UpdateQuestionStatus :one
+UPDATE product_questions
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: InsertAnswer :one
+INSERT INTO product_answers (
+  id,
+  question_id,
+  responder_id,
+  responder_role,
+  body,
+  status,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, NOW(), NOW()
+) RETURNING *;
+
+-- name: GetVisibleAnswersByQuestionIDs :many
+SELECT * FROM product_answers
+WHERE question_id = ANY(sqlc.arg(question_ids)::uuid[]) AND status = 'visible'
+ORDER BY created_at ASC;
+
+-- name: UpdateAnswerStatus :one
+-- The previous status is read under the row lock, so concurrent moderators agree on which of
+-- them made the answer visible.
+WITH previous AS (
+    SELECT p.id, p.status FROM product_answers AS p WHERE p.id = sqlc.arg(id) FOR UPDATE
+)
+UPDATE product_answers AS a
+SET status = sqlc.arg(status), updated_at = NOW()
+FROM previous
+WHERE a.id = previous.id
+RETURNING sqlc.embed(a), previous.status AS previous_status;
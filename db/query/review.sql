@@ -0,0 +1,96 @@
+-- name: InsertReview :one
+INSERT INTO product_reviews (
+  id,
+  product_id,
+  user_id,
+  rating,
+  body,
+  verified_purchase,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, NOW(), NOW()
+) RETURNING *;
+
+-- name: GetReviewByID :one
+SELECT * FROM product_reviews
+WHERE id = $1;
+
+-- name: LockReviewByID :one
+SELECT * FROM product_reviews
+WHERE id = $1
+FOR UPDATE;
+
+-- name: LockReviewByUserAndProduct :one
+SELECT * FROM product_reviews
+WHERE user_id = $1 AND product_id = $2
+FOR UPDATE;
+
+-- name: GetReviewsByProductID :many
+SELECT * FROM product_reviews
+WHERE product_id = sqlc.arg(product_id) AND hidden = FALSE
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'highest' THEN rating END DESC,
+  CASE WHEN sqlc.arg(sort)::text = 'lowest' THEN rating END ASC,
+  created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountReviewsByProductID :one
+SELECT COUNT(*) FROM product_reviews
+WHERE product_id = $1 AND hidden = FALSE;
+
+-- name: UpdateReview :one
+UPDATE product_reviews
+SET rating = $2, body = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReview :one
+DELETE FROM product_reviews
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateReviewReply :one
+UPDATE product_reviews
+SET seller_reply = $2, seller_replied_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: InsertReviewReport :execrows
+INSERT INTO review_reports (
+  review_id,
+  reporter_id,
+  reason,
+  created_at
+) VALUES (
+  $1, $2, $3, NOW()
+) ON CONFLICT (review_id, reporter_id) DO NOTHING;
+
+-- name: IncrementReviewReportCount :one
+UPDATE product_reviews
+SET
+  report_count = report_count + 1,
+  hidden = hidden OR report_count + 1 >= sqlc.arg(hide_threshold)::int
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: InsertVerifiedPurchase :exec
+INSERT INTO verified_purchases (
+  user_id,
+  product_id,
+  order_id,
+  purchased_at
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (user_id, product_id) DO NOTHING;
+
+-- name: HasVerifiedPurchase :one
+SELECT EXISTS (
+  SELECT 1 FROM verified_purchases
+  WHERE user_id = $1 AND product_id = $2
+);
+
+-- name: MarkReviewsVerified :exec
+UPDATE product_reviews
+SET verified_purchase = TRUE
+WHERE user_id = $1 AND product_id = ANY(sqlc.arg(product_ids)::uuid[]);
@@ -0,0 +1,33 @@
+-- name: SeedProduct :execrows
+-- Seeding is re-runnable: rows that already exist are left untouched.
+INSERT INTO products (
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, sqlc.arg(created_at), sqlc.arg(created_at)
+)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: SeedReview :execrows
+INSERT INTO product_reviews (
+  id,
+  product_id,
+  user_id,
+  rating,
+  body,
+  verified_purchase,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, sqlc.arg(created_at), sqlc.arg(created_at)
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+package configs
+
+import "time"
+
+type AnalyticsConfig struct {
+	FlushInterval time.Duration `env:"ANALYTICS_FLUSH_INTERVAL" envDefault:"1m" validate:"gt=0"`
+	CounterTTL    time.Duration `env:"ANALYTICS_COUNTER_TTL" envDefault:"72h"`
+	MaxRangeDays  int           `env:"ANALYTICS_MAX_RANGE_DAYS" envDefault:"366"`
+}
@@ -0,0 +1,29 @@
+package configs
+
+import "time"
+
+type AuthConfig struct {
+	// Mode is "remote" (ask the account service), "local" (verify signatures against the JWKS)
+	// or "hybrid" (local first, remote when the key set cannot decide).
+	Mode      string        `env:"AUTH_MODE" envDefault:"remote" validate:"oneof=remote local hybrid"`
+	Audiences []string      `env:"JWT_AUDIENCE,required" envSeparator:","`
+	Issuer    string        `env:"AUTH_ISSUER"`
+	ClockSkew time.Duration `env:"AUTH_CLOCK_SKEW" envDefault:"30s"`
+
+	// Exactly one of JWKSURL and JWKSFile is needed for local and hybrid modes.
+	JWKSURL             string        `env:"AUTH_JWKS_URL"`
+	JWKSFile            string        `env:"AUTH_JWKS_FILE"`
+	JWKSRefreshInterval time.Duration `env:"AUTH_JWKS_REFRESH_INTERVAL" envDefault:"5m"`
+
+	RemoteTimeout     time.Duration `env:"AUTH_REMOTE_TIMEOUT" envDefault:"2s"`
+	RemoteCacheTTL    time.Duration `env:"AUTH_REMOTE_CACHE_TTL" envDefault:"30s"`
+	RemoteNegativeTTL time.Duration `env:"AUTH_REMOTE_NEGATIVE_CACHE_TTL" envDefault:"10s"`
+
+	// Revoked token IDs (jti) are stored by the account service as <prefix><jti> keys in Redis.
+	RevocationEnabled    bool   `env:"AUTH_REVOCATION_ENABLED" envDefault:"true"`
+	RevocationKeyPrefix  string `env:"AUTH_REVOCATION_KEY_PREFIX" envDefault:"auth:revoked:"`
+	RevocationFailClosed bool   `env:"AUTH_REVOCATION_FAIL_CLOSED" envDefault:"false"`
+
+	// RolePermissions grants permissions to roles, as "role=perm,perm" entries separated by ";".
+	RolePermissions []string `env:"AUTH_ROLE_PERMISSIONS" envSeparator:";" envDefault:"admin=product:write:own,product:write:any,cache:admin,moderation:review,config:read;seller=product:write:own,analytics:read:own"`
+}
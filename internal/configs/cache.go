@@ -0,0 +1,15 @@
+package configs
+
+import "time"
+
+type CacheConfig struct {
+	DefaultTTL  time.Duration `env:"CACHE_DEFAULT_TTL" envDefault:"5m"`
+	NegativeTTL time.Duration `env:"CACHE_NEGATIVE_TTL" envDefault:"30s"`
+	// TTLJitter adds up to this fraction of the TTL at random, e.g. 0.1 = +0-10%.
+	TTLJitter float64 `env:"CACHE_TTL_JITTER" envDefault:"0.1" validate:"min=0,max=1"`
+
+	// L1 is the per-replica in-memory layer; keep its TTL short since it is only as fresh as the last broadcast.
+	L1Size              int           `env:"CACHE_L1_SIZE" envDefault:"10000" validate:"min=0"`
+	L1TTL               time.Duration `env:"CACHE_L1_TTL" envDefault:"30s"`
+	InvalidationChannel string        `env:"CACHE_INVALIDATION_CHANNEL" envDefault:"catalog:cache:invalidate"`
+}
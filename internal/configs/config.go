@@ -1,33 +1,221 @@
 package configs
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"reflect"
+	"strings"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
 type AppConfig struct {
-	Database  DatabaseConfig
-	Migration MigrationConfig
-	Redis     RedisConfig
-	GRPC      GrpcConfig
-	Server    ServerConfig
+	// Env is "production" or anything else for development; it also decides whether .env is read.
+	Env      string `env:"ENV" envDefault:"development"`
+	LogLevel string `env:"LOG_LEVEL" validate:"omitempty,oneof=trace debug info warn warning error fatal panic"`
+
+	Database   DatabaseConfig
+	Migration  MigrationConfig
+	Redis      RedisConfig
+	Cache      CacheConfig
+	GRPC       GrpcConfig
+	GRPCServer GRPCServerConfig
+	Server     ServerConfig
+	HTTP       HTTPConfig
+	RabbitMQ   RabbitMQConfig
+	Moderation ModerationConfig
+	Review     ReviewConfig
+	Storefront StorefrontConfig
+	Analytics  AnalyticsConfig
+	Health     HealthConfig
+	Tracing    TracingConfig
+	Auth       AuthConfig
+	RateLimit  RateLimitConfig
+}
+
+// Sources are the layers read on top of the envDefault tags, lowest precedence first: File, the
+// process environment (plus .env outside production), then Overrides.
+type Sources struct {
+	// File is a YAML mapping of setting names to values, e.g. "DB_HOST: db". Lists may be
+	// written as YAML sequences.
+	File string
+	// Overrides are NAME=value pairs, typically from -set flags.
+	Overrides Overrides
+}
+
+// Overrides collects repeated NAME=value command line flags.
+type Overrides []string
+
+func (o *Overrides) String() string {
+	return strings.Join(*o, " ")
+}
+
+func (o *Overrides) Set(value string) error {
+	*o = append(*o, value)
+	return nil
 }
 
-func LoadConfig(log *logrus.Logger) (*AppConfig, error) {
+// LoadConfig layers the sources, resolves secrets mounted as files and validates the result.
+//
+// Any setting marked secret can be read from a file instead: NAME_FILE=/run/secrets/name sets NAME
+// to the file's contents, as with Docker and Kubernetes secrets.
+func LoadConfig(log *logrus.Logger, sources Sources) (*AppConfig, error) {
 	if os.Getenv("ENV") != "production" {
-		log.Info("ENV not production")
 		if err := godotenv.Load(); err != nil {
-			log.Fatalf("Failed to load .env file: %v", err)
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("configs: failed to read .env: %w", err)
+			}
+			log.Debug("No .env file, using the environment only")
+		}
+	}
+
+	known := settings()
+	values := make(map[string]string)
+
+	if sources.File != "" {
+		if err := readFile(sources.File, known, values); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		values[name] = value
+	}
+
+	for _, override := range sources.Overrides {
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("configs: invalid override %q, want NAME=value", override)
 		}
+		if _, ok := known[name]; !ok && !isSecretFile(name, known) {
+			return nil, fmt.Errorf("configs: unknown setting %q", name)
+		}
+		values[name] = value
+	}
+
+	if err := resolveSecretFiles(known, values); err != nil {
+		return nil, err
 	}
+
 	cfg := &AppConfig{}
-	if err := env.Parse(cfg); err != nil {
+	if err := env.Parse(cfg, env.Options{Environment: values}); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
 	log.Info("Configuration loaded successfully")
 	return cfg, nil
 }
+
+// ------- HELPERS -------
+
+// setting describes one env-tagged field of AppConfig.
+type setting struct {
+	name      string
+	separator string
+	secret    bool
+}
+
+func settings() map[string]setting {
+	known := make(map[string]setting)
+	walk(reflect.ValueOf(AppConfig{}), func(field reflect.StructField, _ reflect.Value) {
+		s := settingOf(field)
+		known[s.name] = s
+	})
+	return known
+}
+
+func settingOf(field reflect.StructField) setting {
+	name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+	separator, ok := field.Tag.Lookup("envSeparator")
+	if !ok {
+		separator = ","
+	}
+	return setting{name: name, separator: separator, secret: field.Tag.Get("secret") == "true"}
+}
+
+// walk calls fn for every env-tagged field under v, descending into nested config structs.
+func walk(v reflect.Value, fn func(field reflect.StructField, value reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("env") != "" {
+			fn(field, v.Field(i))
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			walk(v.Field(i), fn)
+		}
+	}
+}
+
+func readFile(path string, known map[string]setting, values map[string]string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("configs: failed to read %s: %w", path, err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("configs: failed to parse %s: %w", path, err)
+	}
+
+	for name, value := range doc {
+		s, ok := known[name]
+		if !ok && !isSecretFile(name, known) {
+			// A typo would otherwise silently fall back to the default.
+			return fmt.Errorf("configs: unknown setting %q in %s", name, path)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			values[name] = ""
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[name] = strings.Join(items, s.separator)
+		case map[string]interface{}:
+			return fmt.Errorf("configs: setting %q in %s must be a scalar or a list", name, path)
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+
+	return nil
+}
+
+func isSecretFile(name string, known map[string]setting) bool {
+	base, ok := strings.CutSuffix(name, "_FILE")
+	return ok && known[base].secret
+}
+
+func resolveSecretFiles(known map[string]setting, values map[string]string) error {
+	for name, s := range known {
+		path := values[name+"_FILE"]
+		if !s.secret || path == "" {
+			continue
+		}
+		if values[name] != "" {
+			return fmt.Errorf("configs: both %s and %s_FILE are set", name, name)
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("configs: failed to read %s_FILE: %w", name, err)
+		}
+		values[name] = strings.TrimRight(string(raw), "\r\n")
+	}
+
+	return nil
+}
@@ -1,14 +1,28 @@
 package configs
 
+import "time"
+
 type DatabaseConfig struct {
 	Host     string `env:"DB_HOST,required"`
 	User     string `env:"DB_USER,required"`
-	Password string `env:"DB_PASSWORD,required"`
+	Password string `env:"DB_PASSWORD,required" secret:"true"`
 	Name     string `env:"DB_NAME,required"`
-	Port     int    `env:"DB_PORT,required"`
-	SslMode  string `env:"DB_SSL_MODE,required"`
+	Port     int    `env:"DB_PORT,required" validate:"min=1,max=65535"`
+	SslMode  string `env:"DB_SSL_MODE,required" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+	// TimeZone is the session time zone, which decides how timestamps are rendered by Postgres.
+	TimeZone string `env:"DB_TIMEZONE" envDefault:"Asia/Jakarta"`
+
+	ConnectTimeout  time.Duration `env:"DB_CONNECT_TIMEOUT" envDefault:"10s" validate:"gt=0"`
+	MaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS" envDefault:"100" validate:"min=1"`
+	MaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" envDefault:"10" validate:"min=0"`
+	ConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" envDefault:"1h" validate:"min=0"`
+	// ConnMaxIdleTime closes connections idle for longer; 0 keeps them until ConnMaxLifetime.
+	ConnMaxIdleTime time.Duration `env:"DB_CONN_MAX_IDLE_TIME" envDefault:"0s" validate:"min=0"`
 }
 
 type MigrationConfig struct {
 	Path string `env:"MIGRATION_PATH,required"`
+	// AutoApply runs pending migrations when the web server starts. Leave it off where several
+	// replicas start at once and apply migrations with catalogctl instead.
+	AutoApply bool `env:"MIGRATION_AUTO_APPLY" envDefault:"false"`
 }
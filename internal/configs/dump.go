@@ -0,0 +1,38 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// Dump returns every setting by name with its effective value. Secrets are replaced by a
+// placeholder, so the result is safe to log or serve to operators.
+func (c *AppConfig) Dump() map[string]string {
+	out := make(map[string]string)
+	walk(reflect.ValueOf(*c), func(field reflect.StructField, value reflect.Value) {
+		s := settingOf(field)
+		formatted := format(value, s.separator)
+		if s.secret && formatted != "" {
+			formatted = redacted
+		}
+		out[s.name] = formatted
+	})
+	return out
+}
+
+// ------- HELPERS -------
+
+func format(value reflect.Value, separator string) string {
+	switch v := value.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case []string:
+		return strings.Join(v, separator)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,77 @@
+package configs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDumpRedactsEverySecret(t *testing.T) {
+	cfg := &AppConfig{}
+
+	// Give every secret a value, whatever its type, so none can slip through as "".
+	secrets := make(map[string]bool)
+	walk(reflect.ValueOf(cfg).Elem(), func(field reflect.StructField, value reflect.Value) {
+		s := settingOf(field)
+		if !s.secret {
+			return
+		}
+		secrets[s.name] = true
+
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString("s3cret")
+		case reflect.Slice:
+			value.Set(reflect.ValueOf([]string{"svc=s3cret", "other=s3cret"}))
+		default:
+			t.Fatalf("secret %s has kind %s, which this test cannot fill", s.name, value.Kind())
+		}
+	})
+	if len(secrets) == 0 {
+		t.Fatal("found no secret settings")
+	}
+
+	dump := cfg.Dump()
+	for name := range secrets {
+		if dump[name] != redacted {
+			t.Errorf("%s = %q, want %q", name, dump[name], redacted)
+		}
+	}
+	for name, value := range dump {
+		if strings.Contains(value, "s3cret") {
+			t.Errorf("%s leaks a secret: %q", name, value)
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting string
+		mutate  func(*AppConfig)
+		want    string
+	}{
+		{name: "empty secret stays empty", setting: "DB_PASSWORD", mutate: func(c *AppConfig) {}, want: ""},
+		{name: "secret is redacted", setting: "REDIS_PASSWORD", mutate: func(c *AppConfig) { c.Redis.Password = "s3cret" }, want: redacted},
+		{name: "plain value", setting: "DB_HOST", mutate: func(c *AppConfig) { c.Database.Host = "db.internal" }, want: "db.internal"},
+		{name: "list joined with its separator", setting: "RATE_LIMIT_RULES", mutate: func(c *AppConfig) { c.RateLimit.Rules = []string{"search=30/1m", "cart=60/1m"} }, want: "search=30/1m;cart=60/1m"},
+		{name: "duration", setting: "HTTP_REQUEST_TIMEOUT", mutate: func(c *AppConfig) { c.HTTP.RequestTimeout = 90 * time.Second }, want: "1m30s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AppConfig{}
+			tt.mutate(cfg)
+
+			dump := cfg.Dump()
+			got, ok := dump[tt.setting]
+			if !ok {
+				t.Fatalf("%s missing from the dump", tt.setting)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.setting, got, tt.want)
+			}
+		})
+	}
+}
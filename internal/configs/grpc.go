@@ -1,5 +1,26 @@
 package configs
 
+import "time"
+
 type GrpcConfig struct {
-	AccountServiceAddress string `env:"ACCOUNT_GRPC_SERVER_ADDRESS,required"`
+	AccountServiceAddress string `env:"ACCOUNT_GRPC_SERVER_ADDRESS,required" validate:"hostname_port"`
+
+	// Insecure dials the account service without TLS. It is meant for local development and is
+	// refused when ENV=production.
+	Insecure bool `env:"ACCOUNT_GRPC_INSECURE" envDefault:"false"`
+	// CAFile verifies the account service; empty uses the system roots. The client certificate
+	// is optional and enables mTLS. ServerName overrides the name checked in its certificate.
+	CAFile     string `env:"ACCOUNT_GRPC_CA_FILE"`
+	CertFile   string `env:"ACCOUNT_GRPC_CLIENT_CERT_FILE"`
+	KeyFile    string `env:"ACCOUNT_GRPC_CLIENT_KEY_FILE"`
+	ServerName string `env:"ACCOUNT_GRPC_SERVER_NAME"`
+
+	// Account lookups are cosmetic for most callers, so keep them on a short leash: each attempt
+	// gets CallTimeout, transient failures are retried with exponential backoff, and a run of
+	// failures opens the breaker.
+	CallTimeout             time.Duration `env:"ACCOUNT_GRPC_CALL_TIMEOUT" envDefault:"1s" validate:"gt=0"`
+	MaxAttempts             int           `env:"ACCOUNT_GRPC_MAX_ATTEMPTS" envDefault:"3" validate:"min=1"`
+	RetryBackoff            time.Duration `env:"ACCOUNT_GRPC_RETRY_BACKOFF" envDefault:"100ms"`
+	BreakerFailureThreshold int           `env:"ACCOUNT_GRPC_BREAKER_FAILURE_THRESHOLD" envDefault:"5" validate:"min=1"`
+	BreakerOpenTimeout      time.Duration `env:"ACCOUNT_GRPC_BREAKER_OPEN_TIMEOUT" envDefault:"15s"`
 }
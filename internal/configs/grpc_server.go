@@ -0,0 +1,12 @@
+package configs
+
+type GRPCServerConfig struct {
+	// Callers presenting a client certificate verified against SERVER_TLS_CLIENT_CA_FILE are
+	// identified by its common name. ServiceTokens are "service=token" entries separated by ";".
+	// Callers without a client certificate send "authorization: Bearer <token>" metadata.
+	ServiceTokens []string `env:"GRPC_SERVICE_TOKENS" envSeparator:";" secret:"true"`
+
+	// MethodAllowlist lists the services allowed to call each method, as "method=service,service"
+	// entries separated by ";". "*" admits any authenticated service; unlisted methods are denied.
+	MethodAllowlist []string `env:"GRPC_METHOD_ALLOWLIST" envSeparator:";" envDefault:"/product.ProductService/GetProducts=*;/product.ProductService/DecreaseStock=orders;/product.ProductService/IncreaseStock=orders"`
+}
@@ -0,0 +1,9 @@
+package configs
+
+import "time"
+
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check so one hung dependency cannot stall the probe.
+	CheckTimeout     time.Duration `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2s" validate:"gt=0"`
+	GRPCSyncInterval time.Duration `env:"HEALTH_GRPC_SYNC_INTERVAL" envDefault:"10s" validate:"gt=0"`
+}
@@ -0,0 +1,33 @@
+package configs
+
+import "time"
+
+type HTTPConfig struct {
+	// CORSAllowOrigins lists the browser origins allowed to call the API; "*" allows any origin
+	// but cannot be combined with credentials.
+	CORSAllowOrigins     []string      `env:"HTTP_CORS_ALLOW_ORIGINS" envSeparator:"," envDefault:"*"`
+	CORSAllowCredentials bool          `env:"HTTP_CORS_ALLOW_CREDENTIALS" envDefault:"false"`
+	CORSMaxAge           time.Duration `env:"HTTP_CORS_MAX_AGE" envDefault:"10m"`
+
+	// HSTS is only sent on TLS requests, or behind a proxy that sets X-Forwarded-Proto: https.
+	HSTSMaxAge            time.Duration `env:"HTTP_HSTS_MAX_AGE" envDefault:"8760h"`
+	HSTSIncludeSubdomains bool          `env:"HTTP_HSTS_INCLUDE_SUBDOMAINS" envDefault:"true"`
+	FrameOptions          string        `env:"HTTP_FRAME_OPTIONS" envDefault:"DENY" validate:"oneof=DENY SAMEORIGIN"`
+
+	// BodyLimit caps request bodies, e.g. "1M". BodyLimitRoutes raises or lowers it for single
+	// routes, as "METHOD /route/template=size" entries separated by ";".
+	BodyLimit       string   `env:"HTTP_BODY_LIMIT" envDefault:"1M"`
+	BodyLimitRoutes []string `env:"HTTP_BODY_LIMIT_ROUTES" envSeparator:";"`
+
+	// RequestTimeout is the deadline given to each handler's context. The read timeouts bound
+	// slow clients before a handler runs.
+	RequestTimeout    time.Duration `env:"HTTP_REQUEST_TIMEOUT" envDefault:"30s" validate:"gt=0"`
+	ReadHeaderTimeout time.Duration `env:"HTTP_READ_HEADER_TIMEOUT" envDefault:"5s"`
+	ReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"30s"`
+	IdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"2m"`
+
+	// Responses of at least CompressionMinLength bytes are compressed with brotli or gzip,
+	// whichever the client prefers.
+	CompressionEnabled   bool `env:"HTTP_COMPRESSION_ENABLED" envDefault:"true"`
+	CompressionMinLength int  `env:"HTTP_COMPRESSION_MIN_LENGTH" envDefault:"1024" validate:"min=0"`
+}
@@ -0,0 +1,6 @@
+package configs
+
+type ModerationConfig struct {
+	BannedKeywords []string `env:"MODERATION_BANNED_KEYWORDS" envSeparator:","`
+	RequireReview  bool     `env:"MODERATION_REQUIRE_REVIEW" envDefault:"false"`
+}
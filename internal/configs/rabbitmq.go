@@ -0,0 +1,10 @@
+package configs
+
+type RabbitMQConfig struct {
+	URL        string `env:"RABBITMQ_URL,required" secret:"true" validate:"url"`
+	BufferSize int    `env:"RABBITMQ_BUFFER_SIZE" envDefault:"1000" validate:"min=1"`
+
+	OrderExchange   string `env:"RABBITMQ_ORDER_EXCHANGE" envDefault:"tokohobby.events"`
+	OrderRoutingKey string `env:"RABBITMQ_ORDER_ROUTING_KEY" envDefault:"order.created"`
+	OrderQueue      string `env:"RABBITMQ_ORDER_QUEUE" envDefault:"catalog.order_created"`
+}
@@ -0,0 +1,22 @@
+package configs
+
+type RateLimitConfig struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+
+	// Rules set the limit of each route group as "group=requests/window" entries separated by ";",
+	// e.g. "search=30/1m". Callers are counted per user when authenticated, otherwise per IP; gRPC
+	// callers per service. A group without a rule is not limited.
+	Rules []string `env:"RATE_LIMIT_RULES" envSeparator:";" envDefault:"public=300/1m;search=30/1m;authenticated=120/1m;cart=60/1m;grpc=1200/1m"`
+
+	// ExemptServices are internal callers never limited, identified by their client certificate
+	// or gRPC service token.
+	ExemptServices []string `env:"RATE_LIMIT_EXEMPT_SERVICES" envSeparator:"," envDefault:"orders"`
+
+	KeyPrefix string `env:"RATE_LIMIT_KEY_PREFIX" envDefault:"ratelimit:"`
+	// FailClosed rejects requests when Redis cannot be reached instead of letting them through.
+	FailClosed bool `env:"RATE_LIMIT_FAIL_CLOSED" envDefault:"false"`
+
+	// TrustedProxies are CIDRs, in addition to loopback and private ranges, whose
+	// X-Forwarded-For header is believed when resolving the client IP.
+	TrustedProxies []string `env:"RATE_LIMIT_TRUSTED_PROXIES" envSeparator:","`
+}
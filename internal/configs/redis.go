@@ -1,7 +1,21 @@
 package configs
 
+import "time"
+
 type RedisConfig struct {
-	// Addr     string `env:"REDIS_ADDR,required"`
-	Password string `env:"REDIS_PASSWORD"`
-	DB       int    `env:"REDIS_DB,required"`
+	Host     string `env:"REDIS_HOST" envDefault:"localhost"`
+	Port     int    `env:"REDIS_PORT" envDefault:"6379" validate:"min=1,max=65535"`
+	Password string `env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `env:"REDIS_DB,required" validate:"min=0"`
+
+	// PoolSize 0 keeps the go-redis default of 10 connections per CPU.
+	PoolSize     int `env:"REDIS_POOL_SIZE" envDefault:"0" validate:"min=0"`
+	MinIdleConns int `env:"REDIS_MIN_IDLE_CONNS" envDefault:"0" validate:"min=0"`
+
+	// Short timeouts so a dead Redis fails fast instead of stacking up requests.
+	DialTimeout      time.Duration `env:"REDIS_DIAL_TIMEOUT" envDefault:"2s" validate:"gt=0"`
+	OperationTimeout time.Duration `env:"REDIS_OPERATION_TIMEOUT" envDefault:"500ms" validate:"gt=0"`
+
+	BreakerFailureThreshold int           `env:"REDIS_BREAKER_FAILURE_THRESHOLD" envDefault:"5" validate:"min=1"`
+	BreakerOpenTimeout      time.Duration `env:"REDIS_BREAKER_OPEN_TIMEOUT" envDefault:"10s" validate:"gt=0"`
 }
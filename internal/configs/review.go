@@ -0,0 +1,5 @@
+package configs
+
+type ReviewConfig struct {
+	ReportHideThreshold int `env:"REVIEW_REPORT_HIDE_THRESHOLD" envDefault:"5" validate:"min=1"`
+}
@@ -1,8 +1,31 @@
 package configs
 
+import "time"
+
 type ServerConfig struct {
-	Port      string `env:"SERVER_PORT,required"`
-	GRPCPort  string `env:"GRPC_PORT,required"`
-	JWTSecret string `env:"JWT_SECRET,required"`
-	Audience  string `env:"JWT_AUDIENCE,required"`
+	Port     string `env:"SERVER_PORT,required" validate:"numeric"`
+	GRPCPort string `env:"GRPC_PORT,required" validate:"numeric"`
+	// MetricsPort serves /metrics over plain HTTP on its own listener, kept off the public
+	// port so only the scraper's network needs to reach it.
+	MetricsPort string `env:"SERVER_METRICS_PORT" envDefault:"9090" validate:"numeric"`
+
+	// ShutdownTimeout bounds the whole shutdown sequence after SIGTERM.
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+
+	// Insecure serves plain HTTP and gRPC. It is meant for local development and is refused
+	// when ENV=production.
+	Insecure bool `env:"SERVER_INSECURE" envDefault:"false"`
+
+	// The HTTP and gRPC servers share one certificate. With a client CA bundle, client
+	// certificates are verified when presented; HTTP can additionally require them.
+	TLSCertFile           string `env:"SERVER_TLS_CERT_FILE"`
+	TLSKeyFile            string `env:"SERVER_TLS_KEY_FILE"`
+	TLSClientCAFile       string `env:"SERVER_TLS_CLIENT_CA_FILE"`
+	HTTPRequireClientCert bool   `env:"SERVER_HTTP_REQUIRE_CLIENT_CERT" envDefault:"false"`
+	// TLSReloadInterval is how often every TLS file, inbound and outbound, is checked for rotation.
+	TLSReloadInterval time.Duration `env:"SERVER_TLS_RELOAD_INTERVAL" envDefault:"1m" validate:"gt=0"`
+
+	// LegacyErrorResponses keeps the old {"error": ...} body for clients that do not send
+	// Accept: application/problem+json.
+	LegacyErrorResponses bool `env:"SERVER_LEGACY_ERROR_RESPONSES" envDefault:"false"`
 }
@@ -0,0 +1,9 @@
+package configs
+
+import "time"
+
+type StorefrontConfig struct {
+	ProfileCacheTTL  time.Duration `env:"STOREFRONT_PROFILE_CACHE_TTL" envDefault:"2m"`
+	FeaturedLimit    int           `env:"STOREFRONT_FEATURED_LIMIT" envDefault:"4"`
+	NewArrivalsLimit int           `env:"STOREFRONT_NEW_ARRIVALS_LIMIT" envDefault:"8"`
+}
@@ -0,0 +1,11 @@
+package configs
+
+type TracingConfig struct {
+	Enabled     bool   `env:"TRACING_ENABLED" envDefault:"false"`
+	ServiceName string `env:"TRACING_SERVICE_NAME" envDefault:"tokohobby-catalog"`
+	// Exporter is "otlp" (gRPC, e.g. a local collector on :4317) or "stdout".
+	Exporter     string  `env:"TRACING_EXPORTER" envDefault:"otlp" validate:"oneof=otlp stdout"`
+	OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT" envDefault:"localhost:4317"`
+	OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE" envDefault:"true"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1.0" validate:"min=0,max=1"`
+}
@@ -0,0 +1,75 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validate checks each setting against its validate tag, then the rules spanning several
+// settings. Every problem is reported at once so a bad deploy is fixed in one round.
+func (c *AppConfig) Validate() error {
+	v := validator.New()
+	// Report settings by the name operators set, not the Go field name.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		return name
+	})
+
+	var problems []string
+	if err := v.Struct(c); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return fmt.Errorf("configs: %w", err)
+		}
+		for _, fieldErr := range validationErrors {
+			rule := fieldErr.Tag()
+			if fieldErr.Param() != "" {
+				rule += "=" + fieldErr.Param()
+			}
+			problems = append(problems, fmt.Sprintf("%s fails %s", fieldErr.Field(), rule))
+		}
+	}
+
+	problems = append(problems, c.crossChecks()...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("configs: invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ------- HELPERS -------
+
+func (c *AppConfig) crossChecks() []string {
+	var problems []string
+
+	if c.Env == "production" && (c.Server.Insecure || c.GRPC.Insecure) {
+		problems = append(problems, "SERVER_INSECURE and ACCOUNT_GRPC_INSECURE are not allowed in production")
+	}
+	if !c.Server.Insecure && (c.Server.TLSCertFile == "" || c.Server.TLSKeyFile == "") {
+		problems = append(problems, "SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE are required unless SERVER_INSECURE is set")
+	}
+	if c.Server.MetricsPort == c.Server.Port || c.Server.MetricsPort == c.Server.GRPCPort {
+		problems = append(problems, "SERVER_METRICS_PORT must differ from SERVER_PORT and GRPC_PORT")
+	}
+	if c.HTTP.CORSAllowCredentials && slices.Contains(c.HTTP.CORSAllowOrigins, "*") {
+		problems = append(problems, "HTTP_CORS_ALLOW_CREDENTIALS cannot be combined with a wildcard origin")
+	}
+	if c.Auth.Mode != "remote" && (c.Auth.JWKSURL == "") == (c.Auth.JWKSFile == "") {
+		problems = append(problems, "AUTH_MODE "+c.Auth.Mode+" needs exactly one of AUTH_JWKS_URL and AUTH_JWKS_FILE")
+	}
+	if _, err := time.LoadLocation(c.Database.TimeZone); err != nil {
+		problems = append(problems, fmt.Sprintf("DB_TIMEZONE %q is not a known time zone", c.Database.TimeZone))
+	}
+	if c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS cannot exceed DB_MAX_OPEN_CONNS")
+	}
+
+	return problems
+}
@@ -0,0 +1,54 @@
+package crons
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+// AnalyticsFlushJob periodically moves analytics counters from Redis into Postgres.
+type AnalyticsFlushJob struct {
+	analyticsSvc services.AnalyticsService
+	interval     time.Duration
+	log          *logrus.Logger
+}
+
+func NewAnalyticsFlushJob(analyticsSvc services.AnalyticsService, interval time.Duration, log *logrus.Logger) *AnalyticsFlushJob {
+	return &AnalyticsFlushJob{
+		analyticsSvc: analyticsSvc,
+		interval:     interval,
+		log:          log,
+	}
+}
+
+// Run blocks until ctx is cancelled, flushing once per interval and one last time on the way out.
+func (j *AnalyticsFlushJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.flush(context.Background())
+			return
+		case <-ticker.C:
+			j.flush(ctx)
+		}
+	}
+}
+
+func (j *AnalyticsFlushJob) flush(ctx context.Context) {
+	flushed, err := j.analyticsSvc.FlushCounters(ctx)
+	if err != nil {
+		logctx.From(ctx, j.log).WithError(err).Error("Analytics flush failed")
+		return
+	}
+
+	if flushed > 0 {
+		logctx.From(ctx, j.log).WithField("product_days", flushed).Info("Analytics counters flushed")
+	}
+}
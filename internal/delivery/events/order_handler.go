@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type OrderEventHandler struct {
+	reviewSvc services.ReviewService
+	log       *logrus.Logger
+}
+
+func NewOrderEventHandler(reviewSvc services.ReviewService, log *logrus.Logger) *OrderEventHandler {
+	return &OrderEventHandler{
+		reviewSvc: reviewSvc,
+		log:       log,
+	}
+}
+
+// HandleOrderCreated marks the ordered products as verified purchases for the buyer.
+func (h *OrderEventHandler) HandleOrderCreated(ctx context.Context, body []byte) error {
+	var event models.OrderCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal order created event: %w", err)
+	}
+
+	logctx.From(ctx, h.log).WithField("order_id", event.OrderID).Info("Received order created event")
+
+	return h.reviewSvc.RecordVerifiedPurchase(ctx, &event)
+}
@@ -1,32 +1,29 @@
 package middlewares
 
 import (
-	"net/http"
 	"strings"
 
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
-	"github.com/sirupsen/logrus"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/problem"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/auth"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
-func RequireRoles(allowedRoles ...string) echo.MiddlewareFunc {
-	roleSet := make(map[string]struct{})
-	for _, r := range allowedRoles {
-		roleSet[r] = struct{}{}
-	}
-
+// RequirePermission lets the request through when the caller's role holds any of perms.
+// Ownership of the target resource is checked later, by the service.
+func RequirePermission(policy *authz.Policy, perms ...authz.Permission) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			role, ok := c.Get("role").(string)
 			if !ok {
-				return c.JSON(http.StatusUnauthorized, models.ErrorResponse{Error: "Unauthorized"})
+				return problem.Respond(c, apperrors.ErrUnauthorized)
 			}
 
-			if _, allowed := roleSet[role]; !allowed {
-				return c.JSON(http.StatusForbidden, models.ErrorResponse{Error: "Access denied"})
+			if !policy.Allows(role, perms...) {
+				return problem.Respond(c, apperrors.ErrAccessDenied)
 			}
 
 			return next(c)
@@ -34,44 +31,32 @@ func RequireRoles(allowedRoles ...string) echo.MiddlewareFunc {
 	}
 }
 
-func AuthMiddleware(authClient *account.AuthClient, jwtSecret string, audience string, log *logrus.Logger) echo.MiddlewareFunc {
+// AuthMiddleware authenticates the bearer token with verifier and exposes the caller's identity
+// to handlers and the request logger.
+func AuthMiddleware(verifier auth.Verifier) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, echo.Map{"message": "Authorization token not found"})
+				return problem.Respond(c, apperrors.ErrUnauthorized.Withf("authorization token not found"))
 			}
 
 			tokenString := authHeader
 			if len(authHeader) > 7 && strings.HasPrefix(authHeader, "Bearer ") {
 				tokenString = authHeader[7:]
 			} else {
-				return c.JSON(http.StatusBadRequest, echo.Map{"message": "Invalid token format (expected Bearer token)"})
+				return problem.Respond(c, apperrors.ErrInvalidToken.Withf("expected a Bearer token"))
 			}
 
-			// check token via rpc
-			isValid, userID, username, role, errMsg, err := authClient.ValidateToken(tokenString)
+			claims, err := verifier.Verify(c.Request().Context(), tokenString)
 			if err != nil {
-				return c.JSON(http.StatusInternalServerError, echo.Map{"message": "Server error during token validation"})
-			}
-
-			if !isValid {
-				return c.JSON(http.StatusUnauthorized, echo.Map{"message": errMsg})
-			}
-
-			// check audience
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				return []byte(jwtSecret), nil
-			}, jwt.WithAudience(audience))
-
-			if err != nil || !token.Valid {
-				log.Warnf("Invalid audience or signature check locally: %v", err)
-				return c.JSON(http.StatusUnauthorized, echo.Map{"message": "Invalid token audience"})
+				return problem.Respond(c, err)
 			}
 
-			c.Set("userID", userID)
-			c.Set("username", username)
-			c.Set("role", role)
+			c.Set("userID", claims.UserID)
+			c.Set("username", claims.Username)
+			c.Set("role", claims.Role)
+			c.SetRequest(c.Request().WithContext(logctx.WithUser(c.Request().Context(), claims.UserID, claims.Role)))
 
 			return next(c)
 		}
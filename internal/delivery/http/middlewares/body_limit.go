@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
+)
+
+// BodyLimit caps request bodies at limit. routes overrides the limit for single routes, as
+// "METHOD /route/template=size" entries, so bulk endpoints can accept more than the rest.
+func BodyLimit(limit string, routes []string) (echo.MiddlewareFunc, error) {
+	if _, err := bytes.Parse(limit); err != nil {
+		return nil, fmt.Errorf("middlewares: invalid body limit %q", limit)
+	}
+
+	overrides := make(map[string]echo.MiddlewareFunc, len(routes))
+	for _, entry := range routes {
+		route, size, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		method, path, ok2 := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok || !ok2 || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("middlewares: invalid body limit route %q, want METHOD /path=size", entry)
+		}
+		if _, err := bytes.Parse(size); err != nil {
+			return nil, fmt.Errorf("middlewares: invalid body limit %q for %s", size, route)
+		}
+		overrides[strings.ToUpper(method)+" "+path] = middleware.BodyLimit(size)
+	}
+
+	byDefault := middleware.BodyLimit(limit)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		limited := byDefault(next)
+		routeLimited := make(map[string]echo.HandlerFunc, len(overrides))
+		for route, mw := range overrides {
+			routeLimited[route] = mw(next)
+		}
+
+		return func(c echo.Context) error {
+			if h, ok := routeLimited[c.Request().Method+" "+c.Path()]; ok {
+				return h(c)
+			}
+			return limited(c)
+		}
+	}, nil
+}
@@ -0,0 +1,296 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+)
+
+const testMinLength = 64
+
+var (
+	smallBody = `{"ok":true}`
+	largeBody = `{"items":"` + strings.Repeat("gundam ", 50) + `"}`
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{acceptEncoding: "", want: ""},
+		{acceptEncoding: "identity", want: ""},
+		{acceptEncoding: "gzip", want: encodingGzip},
+		{acceptEncoding: "br", want: encodingBrotli},
+		{acceptEncoding: "gzip, deflate, br", want: encodingBrotli},
+		{acceptEncoding: "GZIP", want: encodingGzip},
+		{acceptEncoding: "br;q=0.5, gzip;q=0.8", want: encodingGzip},
+		{acceptEncoding: "br;q=0.8, gzip;q=0.8", want: encodingBrotli},
+		{acceptEncoding: "br;q=0, gzip", want: encodingGzip},
+		{acceptEncoding: "br;q=0, gzip;q=0", want: ""},
+		{acceptEncoding: "br;q=high, gzip;q=0.1", want: encodingGzip},
+		{acceptEncoding: " gzip ; q=0.3 ", want: encodingGzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+				t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		handler        echo.HandlerFunc
+		wantStatus     int
+		wantEncoding   string
+		wantBody       string
+	}{
+		{
+			name:           "small body passes through",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusCreated, echo.MIMEApplicationJSON, smallBody),
+			wantStatus:     http.StatusCreated,
+			wantBody:       smallBody,
+		},
+		{
+			name:           "large body is gzipped",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusOK, echo.MIMEApplicationJSON, largeBody),
+			wantStatus:     http.StatusOK,
+			wantEncoding:   encodingGzip,
+			wantBody:       largeBody,
+		},
+		{
+			name:           "large body is brotli encoded",
+			acceptEncoding: "gzip, br",
+			handler:        blob(http.StatusOK, echo.MIMEApplicationJSON, largeBody),
+			wantStatus:     http.StatusOK,
+			wantEncoding:   encodingBrotli,
+			wantBody:       largeBody,
+		},
+		{
+			name:           "error status is kept",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusUnprocessableEntity, "application/problem+json", largeBody),
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantEncoding:   encodingGzip,
+			wantBody:       largeBody,
+		},
+		{
+			name:       "client without compression",
+			handler:    blob(http.StatusOK, echo.MIMEApplicationJSON, largeBody),
+			wantStatus: http.StatusOK,
+			wantBody:   largeBody,
+		},
+		{
+			name:           "no content",
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.NoContent(http.StatusNoContent) },
+			wantStatus:     http.StatusNoContent,
+		},
+		{
+			name:           "204 with a body is left alone",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusNoContent, echo.MIMEApplicationJSON, largeBody),
+			wantStatus:     http.StatusNoContent,
+			wantBody:       largeBody,
+		},
+		{
+			name:           "304 is left alone",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusNotModified, echo.MIMEApplicationJSON, largeBody),
+			wantStatus:     http.StatusNotModified,
+			wantBody:       largeBody,
+		},
+		{
+			name:           "image is not compressed",
+			acceptEncoding: "gzip",
+			handler:        blob(http.StatusOK, "image/png", largeBody),
+			wantStatus:     http.StatusOK,
+			wantBody:       largeBody,
+		},
+		{
+			name:           "already encoded body is not compressed again",
+			acceptEncoding: "gzip",
+			handler: func(c echo.Context) error {
+				c.Response().Header().Set(echo.HeaderContentEncoding, "identity")
+				return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, []byte(largeBody))
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: "identity",
+			wantBody:     largeBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newCompressServer(tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set(echo.HeaderAcceptEncoding, tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(echo.HeaderContentEncoding); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get(echo.HeaderVary); got != echo.HeaderAcceptEncoding {
+				t.Errorf("Vary = %q, want %q", got, echo.HeaderAcceptEncoding)
+			}
+			if tt.wantEncoding == encodingGzip || tt.wantEncoding == encodingBrotli {
+				if got := rec.Header().Get(echo.HeaderContentLength); got != "" {
+					t.Errorf("Content-Length = %q on a compressed body, want none", got)
+				}
+			}
+			if got := decodeBody(t, rec); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCompressDropsStaleContentLength(t *testing.T) {
+	e := newCompressServer(func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentLength, "370")
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, []byte(largeBody))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(echo.HeaderContentLength); got != "" {
+		t.Errorf("Content-Length = %q, want it removed once the body is compressed", got)
+	}
+	if got := decodeBody(t, rec); got != largeBody {
+		t.Errorf("body = %q, want %q", got, largeBody)
+	}
+}
+
+func TestCompressSkipsRangeAndHead(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		method string
+		header string
+	}{
+		{name: "range", method: http.MethodGet, header: "Range"},
+		{name: "head", method: http.MethodHead},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Add(tt.method, "/", blob(http.StatusOK, echo.MIMEApplicationJSON, largeBody), Compress(testMinLength))
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+			if tt.header != "" {
+				req.Header.Set(tt.header, "bytes=0-10")
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(echo.HeaderContentEncoding); got != "" {
+				t.Errorf("Content-Encoding = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestCompressFlush(t *testing.T) {
+	tests := []struct {
+		name         string
+		first        string
+		wantEncoding string
+	}{
+		// A flush before the threshold commits to an uncompressed stream.
+		{name: "before the threshold", first: smallBody, wantEncoding: ""},
+		{name: "after the threshold", first: largeBody, wantEncoding: encodingGzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newCompressServer(func(c echo.Context) error {
+				res := c.Response()
+				res.Header().Set(echo.HeaderContentType, "text/event-stream")
+				res.WriteHeader(http.StatusOK)
+				if _, err := res.Write([]byte(tt.first)); err != nil {
+					return err
+				}
+				res.Flush()
+				_, err := res.Write([]byte(largeBody))
+				return err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if !rec.Flushed {
+				t.Error("response was not flushed")
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200", rec.Code)
+			}
+			if got := rec.Header().Get(echo.HeaderContentEncoding); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got, want := decodeBody(t, rec), tt.first+largeBody; got != want {
+				t.Errorf("body = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func newCompressServer(handler echo.HandlerFunc) *echo.Echo {
+	e := echo.New()
+	e.GET("/", handler, Compress(testMinLength))
+	return e
+}
+
+func blob(status int, contentType, body string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.Blob(status, contentType, []byte(body))
+	}
+}
+
+// decodeBody returns the response body, decoded according to its Content-Encoding.
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var r io.Reader = rec.Body
+	switch rec.Header().Get(echo.HeaderContentEncoding) {
+	case encodingGzip:
+		gr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader: %v", err)
+		}
+		r = gr
+	case encodingBrotli:
+		r = brotli.NewReader(rec.Body)
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	return string(body)
+}
@@ -1,31 +1,55 @@
 package middlewares
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/problem"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 )
 
+// LoggingMiddleware puts the request ID into the request context for
+// downstream logging and writes one access log line per request.
 func LoggingMiddleware(log *logrus.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			start := time.Now()
 			req := c.Request()
 			res := c.Response()
 
-			log.WithFields(logrus.Fields{
-				"request_id": res.Header().Get(echo.HeaderXRequestID),
-				"ip_address": c.RealIP(),
-				"method":     req.Method,
-				"path":       req.URL.Path,
-			}).Info("Received new request")
+			ctx := logctx.WithRequestID(req.Context(), res.Header().Get(echo.HeaderXRequestID))
+			c.SetRequest(req.WithContext(ctx))
 
 			err := next(c)
+			if err != nil {
+				c.Error(err)
+			} else if handlerErr, ok := c.Get(problem.ErrorKey).(error); ok {
+				err = handlerErr
+			}
 
-			log.WithFields(logrus.Fields{
-				"request_id": res.Header().Get(echo.HeaderXRequestID),
+			// Identity is added by AuthMiddleware further down the chain.
+			entry := logctx.From(c.Request().Context(), log).WithFields(logrus.Fields{
+				"ip_address": c.RealIP(),
+				"method":     req.Method,
+				"route":      c.Path(),
+				"path":       req.URL.Path,
 				"status":     res.Status,
-			}).Info("Finished processing request")
+				"latency_ms": time.Since(start).Milliseconds(),
+			})
+
+			if res.Status >= http.StatusInternalServerError {
+				if err != nil {
+					entry = entry.WithError(err)
+				}
+				entry.Error("Request failed")
+			} else {
+				entry.Info("Request completed")
+			}
 
-			return err
+			return nil
 		}
 	}
 }
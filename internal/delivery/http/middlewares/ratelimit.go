@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/problem"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpcauth"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/ratelimit"
+)
+
+// RateLimit counts requests against group's rule, per user after AuthMiddleware and per client IP
+// before it. Internal services presenting a verified client certificate can be exempted. Responses
+// carry the RateLimit-* headers from the IETF draft.
+func RateLimit(limiter *ratelimit.Limiter, group string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if state := c.Request().TLS; state != nil && len(state.VerifiedChains) > 0 {
+				if limiter.Exempt(grpcauth.CertificateIdentity(state.VerifiedChains[0][0])) {
+					metrics.RateLimitDecisions.WithLabelValues(group, metrics.RateLimitExempt).Inc()
+					return next(c)
+				}
+			}
+
+			identity := "ip:" + c.RealIP()
+			if userID, ok := c.Get("userID").(string); ok && userID != "" {
+				identity = "user:" + userID
+			}
+
+			decision := limiter.Allow(c.Request().Context(), group, identity)
+			if decision.Limit == 0 {
+				return next(c)
+			}
+
+			reset := strconv.Itoa(int(math.Ceil(decision.Reset.Seconds())))
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			header.Set("RateLimit-Reset", reset)
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", decision.Limit, int(decision.Window.Seconds())))
+
+			if !decision.Allowed {
+				header.Set(echo.HeaderRetryAfter, reset)
+				return problem.Respond(c, apperrors.ErrRateLimited.Withf("rate limit of %d requests per %s exceeded", decision.Limit, decision.Window))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package middlewares
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/ratelimit"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+// The window is long enough that no test straddles a boundary.
+const testRule = "search=2/1h"
+
+func TestRateLimitHeaders(t *testing.T) {
+	e, _ := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}}, "search")
+
+	var recs []*httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		recs = append(recs, rec)
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		rec := recs[i]
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d = %d, want 200", i+1, rec.Code)
+		}
+		if got := rec.Header().Get("RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d RateLimit-Remaining = %q, want %q", i+1, got, wantRemaining)
+		}
+		if got := rec.Header().Get(echo.HeaderRetryAfter); got != "" {
+			t.Errorf("request %d Retry-After = %q, want none on an allowed request", i+1, got)
+		}
+	}
+
+	limited := recs[2]
+	if limited.Code != http.StatusTooManyRequests {
+		t.Fatalf("third request = %d, want 429", limited.Code)
+	}
+
+	header := limited.Header()
+	for name, want := range map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Policy":    "2;w=3600",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	reset, err := strconv.Atoi(header.Get("RateLimit-Reset"))
+	if err != nil || reset < 1 || reset > 3600 {
+		t.Errorf("RateLimit-Reset = %q, want seconds within the window", header.Get("RateLimit-Reset"))
+	}
+	if got := header.Get(echo.HeaderRetryAfter); got != header.Get("RateLimit-Reset") {
+		t.Errorf("Retry-After = %q, want it to match RateLimit-Reset %q", got, header.Get("RateLimit-Reset"))
+	}
+}
+
+func TestRateLimitIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantKey string
+	}{
+		{name: "authenticated user", userID: "u1", wantKey: "ratelimit:search:user:u1:"},
+		{name: "anonymous client by IP", wantKey: "ratelimit:search:ip:192.0.2.1:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, mr := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}}, "search")
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.userID != "" {
+				req.Header.Set("X-Test-User", tt.userID)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			keys := mr.Keys()
+			if len(keys) != 1 || !strings.HasPrefix(keys[0], tt.wantKey) {
+				t.Errorf("keys = %v, want one counter under %q", keys, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestRateLimitUsersBehindOneIPHaveTheirOwnBudget(t *testing.T) {
+	e, _ := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}}, "search")
+
+	for _, user := range []string{"u1", "u1", "u2", "u2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test-User", user)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("request by %s = %d, want 200", user, rec.Code)
+		}
+	}
+}
+
+func TestRateLimitCertificateExemption(t *testing.T) {
+	orders := &x509.Certificate{Subject: pkix.Name{CommonName: "orders"}}
+	search := &x509.Certificate{Subject: pkix.Name{CommonName: "search"}}
+
+	tests := []struct {
+		name        string
+		state       *tls.ConnectionState
+		wantLimited bool
+	}{
+		{name: "verified exempt service", state: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{orders}}}},
+		{name: "verified service not exempt", state: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{search}}}, wantLimited: true},
+		{name: "unverified certificate", state: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{orders}}, wantLimited: true},
+		{name: "plain HTTP", wantLimited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, mr := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}, ExemptServices: []string{"orders"}}, "search")
+
+			var last *httptest.ResponseRecorder
+			for i := 0; i < 3; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.TLS = tt.state
+				last = httptest.NewRecorder()
+				e.ServeHTTP(last, req)
+			}
+
+			if limited := last.Code == http.StatusTooManyRequests; limited != tt.wantLimited {
+				t.Errorf("third request = %d, want limited %t", last.Code, tt.wantLimited)
+			}
+			if !tt.wantLimited {
+				if got := last.Header().Get("RateLimit-Limit"); got != "" {
+					t.Errorf("RateLimit-Limit = %q, want no headers for an exempt caller", got)
+				}
+				if keys := mr.Keys(); len(keys) != 0 {
+					t.Errorf("keys = %v, want an exempt caller not counted", keys)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitRedisUnavailable(t *testing.T) {
+	tests := []struct {
+		name       string
+		failClosed bool
+		want       int
+	}{
+		{name: "fail open", want: http.StatusOK},
+		{name: "fail closed", failClosed: true, want: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, mr := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}, FailClosed: tt.failClosed}, "search")
+			mr.Close()
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitGroupWithoutRule(t *testing.T) {
+	e, mr := newRateLimitServer(t, configs.RateLimitConfig{Rules: []string{testRule}}, "public")
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("request %d = %d with RateLimit-Limit %q, want 200 without headers", i+1, rec.Code, rec.Header().Get("RateLimit-Limit"))
+		}
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("keys = %v, want nothing counted", keys)
+	}
+}
+
+// ------- HELPERS -------
+
+// newRateLimitServer serves GET / behind RateLimit for group. X-Test-User stands in for the user
+// AuthMiddleware would have identified.
+func newRateLimitServer(t *testing.T, cfg configs.RateLimitConfig, group string) (*echo.Echo, *miniredis.Miniredis) {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	mr := miniredis.RunT(t)
+	port, _ := strconv.Atoi(mr.Port())
+
+	client, err := customRedis.NewRedisClient(&configs.RedisConfig{
+		Host:                    mr.Host(),
+		Port:                    port,
+		DialTimeout:             time.Second,
+		OperationTimeout:        time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, log)
+	if err != nil {
+		t.Fatalf("NewRedisClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	cfg.Enabled = true
+	cfg.KeyPrefix = "ratelimit:"
+	limiter, err := ratelimit.New(&cfg, client, log)
+	if err != nil {
+		t.Fatalf("ratelimit.New: %v", err)
+	}
+
+	identify := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if user := c.Request().Header.Get("X-Test-User"); user != "" {
+				c.Set("userID", user)
+			}
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, identify, RateLimit(limiter, group))
+
+	return e, mr
+}
@@ -0,0 +1,144 @@
+// Package problem renders API errors as RFC 7807 problem+json documents, with a
+// per-deployment switch back to the legacy {"error": ...} body for older clients.
+package problem
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+const (
+	ContentType = "application/problem+json"
+
+	// typeBase prefixes the error code to form the problem type URI.
+	typeBase = "/problems/"
+
+	legacyKey = "problem.legacy"
+
+	// ErrorKey holds the original error of a 5xx response for the access log.
+	ErrorKey = "error"
+)
+
+// Middleware chooses the error format per request. With legacy enabled, clients still get the
+// old body unless they explicitly accept problem+json.
+func Middleware(legacy bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if legacy && !strings.Contains(c.Request().Header.Get(echo.HeaderAccept), ContentType) {
+				c.Set(legacyKey, true)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Respond writes err as the error body. Domain errors carry their own status, code and
+// client-safe message; anything else is reported as an opaque 500.
+func Respond(c echo.Context, err error) error {
+	appErr := apperrors.From(err)
+	status := appErr.Kind.HTTPStatus()
+
+	if status >= http.StatusInternalServerError {
+		// The body hides the cause, so keep it for the access log.
+		c.Set(ErrorKey, err)
+	}
+
+	if legacy, _ := c.Get(legacyKey).(bool); legacy {
+		return respondLegacy(c, status, appErr)
+	}
+
+	lang := language(c.Request().Header.Get("Accept-Language"))
+	var fieldErrors []models.ProblemFieldError
+	for _, d := range appErr.Details {
+		fieldErrors = append(fieldErrors, models.ProblemFieldError{
+			Field:   d.Field,
+			Rule:    d.Rule,
+			Message: d.LocalizedMessage(lang),
+		})
+	}
+
+	return write(c, models.ProblemDetails{
+		Type:     typeBase + appErr.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: requestID(c),
+		Code:     appErr.Code,
+		Errors:   fieldErrors,
+	})
+}
+
+// HTTPErrorHandler renders errors that escape the handlers, such as unknown routes or
+// methods, in the same shape as handler errors.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	he, ok := err.(*echo.HTTPError)
+	if !ok || he.Code >= http.StatusInternalServerError {
+		_ = Respond(c, err)
+		return
+	}
+
+	detail := fmt.Sprint(he.Message)
+	if legacy, _ := c.Get(legacyKey).(bool); legacy {
+		_ = c.JSON(he.Code, models.ErrorResponse{Error: detail})
+		return
+	}
+
+	_ = write(c, models.ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(he.Code),
+		Status:   he.Code,
+		Detail:   detail,
+		Instance: requestID(c),
+	})
+}
+
+// ------- HELPERS -------
+
+func write(c echo.Context, p models.ProblemDetails) error {
+	c.Response().Header().Set(echo.HeaderContentType, ContentType)
+	return c.JSON(p.Status, p)
+}
+
+func respondLegacy(c echo.Context, status int, appErr *apperrors.Error) error {
+	var details []models.ErrorFieldDetail
+	for _, d := range appErr.Details {
+		details = append(details, models.ErrorFieldDetail{Field: d.Field, Message: d.LocalizedMessage(apperrors.DefaultLanguage)})
+	}
+
+	return c.JSON(status, models.ErrorResponse{
+		Error:   appErr.Message,
+		Code:    appErr.Code,
+		Details: details,
+	})
+}
+
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return logctx.FieldsFrom(c.Request().Context()).RequestID
+}
+
+// language picks the first supported primary language from an Accept-Language header.
+func language(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if apperrors.SupportsLanguage(primary) {
+			return primary
+		}
+	}
+
+	return apperrors.DefaultLanguage
+}
@@ -3,35 +3,92 @@ package routes
 import (
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/middlewares"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/handlers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/ratelimit"
 
 	"github.com/labstack/echo/v4"
 )
 
-func InitRoutes(e *echo.Echo, productHandler *handlers.ProductHandler, cartHandler *handlers.CartHandler, authMiddleware echo.MiddlewareFunc) {
+func InitRoutes(e *echo.Echo, productHandler *handlers.ProductHandler, cartHandler *handlers.CartHandler, moderationHandler *handlers.ModerationHandler, reviewHandler *handlers.ReviewHandler, questionHandler *handlers.QuestionHandler, storefrontHandler *handlers.StorefrontHandler, analyticsHandler *handlers.AnalyticsHandler, healthHandler *handlers.HealthHandler, configHandler *handlers.ConfigHandler, authMiddleware echo.MiddlewareFunc, policy *authz.Policy, limiter *ratelimit.Limiter) {
+
+	e.GET("/healthz", healthHandler.Liveness())
+	e.GET("/readyz", healthHandler.Readiness())
 
 	api := e.Group("/api")
 
-	productPublic := api.Group("/products")
+	productPublic := api.Group("/products", middlewares.RateLimit(limiter, "public"))
 	{
 		productPublic.GET("/", productHandler.GetAllProducts())
-		productPublic.GET("/name/:name", productHandler.GetProductsByName())
+		productPublic.GET("/name/:name", productHandler.GetProductsByName(), middlewares.RateLimit(limiter, "search"))
 		productPublic.GET("/category/:type", productHandler.GetProductsByType())
 		productPublic.GET("/:id", productHandler.GetProductByID())
+		productPublic.GET("/:id/reviews", reviewHandler.GetProductReviews())
+		productPublic.GET("/:id/questions", questionHandler.GetProductQuestions())
 		productPublic.GET("/seller/:seller_id", productHandler.GetProductsBySellerID())
 	}
 
+	sellers := api.Group("/sellers", middlewares.RateLimit(limiter, "public"))
+	{
+		sellers.GET("/:seller_id/storefront", storefrontHandler.GetStorefront())
+		sellers.GET("/:seller_id/products", storefrontHandler.GetSellerProducts())
+	}
+
 	protectedApi := api
 	protectedApi.Use(authMiddleware)
 
-	productProtected := protectedApi.Group("/products")
+	canWriteProducts := middlewares.RequirePermission(policy, authz.ProductWriteOwn, authz.ProductWriteAny)
+	authenticatedLimit := middlewares.RateLimit(limiter, "authenticated")
+
+	productProtected := protectedApi.Group("/products", authenticatedLimit)
+	{
+		productProtected.POST("/", productHandler.CreateProduct(), canWriteProducts)
+		productProtected.GET("/me", productHandler.GetMyProducts(), canWriteProducts)
+		productProtected.POST("/:product_id/submit", moderationHandler.SubmitProduct(), canWriteProducts)
+		productProtected.POST("/:product_id/archive", moderationHandler.ArchiveProduct(), canWriteProducts)
+		productProtected.POST("/:product_id/reviews", reviewHandler.CreateReview())
+		productProtected.PUT("/:product_id/reviews", reviewHandler.UpdateReview())
+		productProtected.DELETE("/:product_id/reviews", reviewHandler.DeleteReview())
+		productProtected.POST("/:product_id/questions", questionHandler.AskQuestion())
+		productProtected.PUT("/:product_id", productHandler.UpdateProduct(), canWriteProducts)
+		productProtected.DELETE("/:product_id", productHandler.DeleteProduct(), canWriteProducts)
+		productProtected.DELETE("/clear-cache", productHandler.ClearProductCaches(), middlewares.RequirePermission(policy, authz.CacheAdmin))
+	}
+
+	reviews := protectedApi.Group("/reviews", authenticatedLimit)
+	{
+		reviews.POST("/:review_id/reply", reviewHandler.ReplyToReview(), canWriteProducts)
+		reviews.POST("/:review_id/report", reviewHandler.ReportReview())
+	}
+
+	questions := protectedApi.Group("/questions", authenticatedLimit)
+	{
+		questions.POST("/:question_id/answers", questionHandler.AnswerQuestion(), canWriteProducts)
+	}
+
+	moderation := protectedApi.Group("/admin/products", authenticatedLimit, middlewares.RequirePermission(policy, authz.ModerationReview))
+	{
+		moderation.GET("/", moderationHandler.GetProductsByStatus())
+		moderation.POST("/:product_id/approve", moderationHandler.ApproveProduct())
+		moderation.POST("/:product_id/reject", moderationHandler.RejectProduct())
+		moderation.POST("/:product_id/suspend", moderationHandler.SuspendProduct())
+	}
+
+	qaModeration := protectedApi.Group("/admin", authenticatedLimit, middlewares.RequirePermission(policy, authz.ModerationReview))
+	{
+		qaModeration.GET("/questions", questionHandler.GetQuestionsByStatus())
+		qaModeration.PUT("/questions/:question_id/status", questionHandler.SetQuestionStatus())
+		qaModeration.PUT("/answers/:answer_id/status", questionHandler.SetAnswerStatus())
+	}
+
+	protectedApi.GET("/admin/config", configHandler.GetConfig(), authenticatedLimit, middlewares.RequirePermission(policy, authz.ConfigRead))
+
+	analytics := protectedApi.Group("/analytics", authenticatedLimit, middlewares.RequirePermission(policy, authz.AnalyticsReadOwn))
 	{
-		productProtected.POST("/", productHandler.CreateProduct(), middlewares.RequireRoles("admin", "seller"))
-		productProtected.PUT("/:product_id", productHandler.UpdateProduct(), middlewares.RequireRoles("admin", "seller"))
-		productProtected.DELETE("/:product_id", productHandler.DeleteProduct(), middlewares.RequireRoles("admin", "seller"))
-		productProtected.DELETE("/clear-cache", productHandler.ClearProductCaches())
+		analytics.GET("/shop", analyticsHandler.GetShopAnalytics())
+		analytics.GET("/products/:product_id", analyticsHandler.GetProductAnalytics())
 	}
 
-	cart := protectedApi.Group("/cart")
+	cart := protectedApi.Group("/cart", middlewares.RateLimit(limiter, "cart"))
 	{
 		cart.GET("/", cartHandler.GetCartItemsByUserID())
 		cart.POST("/:product_id", cartHandler.AddToCart())
@@ -0,0 +1,105 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/handlers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/health"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/ratelimit"
+)
+
+// Only denials are exercised: the handlers have no services behind them.
+func TestPermissionDenials(t *testing.T) {
+	e := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		role   string
+		want   int
+	}{
+		{name: "buyer clearing the cache", method: http.MethodDelete, path: "/api/products/clear-cache", role: "buyer", want: http.StatusForbidden},
+		{name: "seller clearing the cache", method: http.MethodDelete, path: "/api/products/clear-cache", role: "seller", want: http.StatusForbidden},
+		{name: "buyer creating a product", method: http.MethodPost, path: "/api/products/", role: "buyer", want: http.StatusForbidden},
+		{name: "seller reading the config", method: http.MethodGet, path: "/api/admin/config", role: "seller", want: http.StatusForbidden},
+		{name: "buyer moderating", method: http.MethodGet, path: "/api/admin/products/", role: "buyer", want: http.StatusForbidden},
+		{name: "no role", method: http.MethodDelete, path: "/api/products/clear-cache", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				req.Header.Set("X-Test-Role", tt.role)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("%s %s as %q = %d, want %d", tt.method, tt.path, tt.role, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func newTestServer(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	field, _ := reflect.TypeOf(configs.AuthConfig{}).FieldByName("RolePermissions")
+	policy, err := authz.NewPolicy(strings.Split(field.Tag.Get("envDefault"), field.Tag.Get("envSeparator")))
+	if err != nil {
+		t.Fatalf("NewPolicy: %v", err)
+	}
+
+	limiter, err := ratelimit.New(&configs.RateLimitConfig{}, nil, log)
+	if err != nil {
+		t.Fatalf("ratelimit.New: %v", err)
+	}
+
+	// Stands in for AuthMiddleware: the role comes straight from a test header.
+	authMiddleware := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("userID", "3f1c0c9e-8d1e-4a53-9d53-1c6a4bde0b11")
+			if role := c.Request().Header.Get("X-Test-Role"); role != "" {
+				c.Set("role", role)
+			}
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	InitRoutes(e,
+		&handlers.ProductHandler{},
+		&handlers.CartHandler{},
+		&handlers.ModerationHandler{},
+		&handlers.ReviewHandler{},
+		&handlers.QuestionHandler{},
+		&handlers.StorefrontHandler{},
+		&handlers.AnalyticsHandler{},
+		handlers.NewHealthHandler(health.NewChecker(time.Second), log),
+		handlers.NewConfigHandler(nil),
+		authMiddleware,
+		policy,
+		limiter,
+	)
+
+	return e
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DailyStat struct {
+	Date          time.Time
+	Views         int
+	UniqueViewers int
+	AddToCarts    int
+	Orders        int
+	UnitsSold     int
+}
+
+// Funnel is the view -> add-to-cart -> order conversion summary over a date range.
+type Funnel struct {
+	Views           int
+	UniqueViewers   int
+	AddToCarts      int
+	Orders          int
+	UnitsSold       int
+	ViewToCartRate  float64
+	CartToOrderRate float64
+	ViewToOrderRate float64
+}
+
+type ProductAnalytics struct {
+	ProductID   uuid.UUID
+	ProductName string
+	Funnel      Funnel
+	Series      []DailyStat
+}
+
+type ShopAnalytics struct {
+	SellerID uuid.UUID
+	From     time.Time
+	To       time.Time
+	Funnel   Funnel
+	Series   []DailyStat
+	Products []ProductAnalytics
+}
@@ -17,6 +17,12 @@ type Product struct {
 	Type        string    `json:"type"`
 	Description string    `gorm:"type:text" json:"description"`
 
+	Status       ProductStatus `json:"status"`
+	StatusReason string        `json:"statusReason,omitempty"`
+
+	RatingAverage float64 `json:"ratingAverage"`
+	RatingCount   int     `json:"ratingCount"`
+
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
@@ -25,3 +31,36 @@ type Product struct {
 func (c *Product) TableName() string {
 	return "product"
 }
+
+type ProductStatus string
+
+const (
+	ProductStatusDraft         ProductStatus = "draft"
+	ProductStatusPendingReview ProductStatus = "pending_review"
+	ProductStatusPublished     ProductStatus = "published"
+	ProductStatusSuspended     ProductStatus = "suspended"
+	ProductStatusArchived      ProductStatus = "archived"
+)
+
+// productStatusTransitions lists, for every status, the statuses a product may move to next.
+var productStatusTransitions = map[ProductStatus][]ProductStatus{
+	ProductStatusDraft:         {ProductStatusPendingReview, ProductStatusPublished, ProductStatusArchived},
+	ProductStatusPendingReview: {ProductStatusPublished, ProductStatusDraft, ProductStatusArchived},
+	ProductStatusPublished:     {ProductStatusPendingReview, ProductStatusSuspended, ProductStatusArchived},
+	ProductStatusSuspended:     {ProductStatusPendingReview, ProductStatusPublished, ProductStatusArchived},
+	ProductStatusArchived:      {ProductStatusDraft},
+}
+
+func (s ProductStatus) IsValid() bool {
+	_, ok := productStatusTransitions[s]
+	return ok
+}
+
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	for _, allowed := range productStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
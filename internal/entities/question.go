@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContentStatus string
+
+const (
+	ContentStatusVisible ContentStatus = "visible"
+	ContentStatusFlagged ContentStatus = "flagged"
+	ContentStatusHidden  ContentStatus = "hidden"
+)
+
+func (s ContentStatus) IsValid() bool {
+	switch s {
+	case ContentStatusVisible, ContentStatusFlagged, ContentStatusHidden:
+		return true
+	}
+	return false
+}
+
+type Question struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	AskerID   uuid.UUID
+	Body      string
+	Status    ContentStatus
+	Answers   []Answer
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Answer struct {
+	ID            uuid.UUID
+	QuestionID    uuid.UUID
+	ResponderID   uuid.UUID
+	ResponderRole string
+	Body          string
+	Status        ContentStatus
+	CreatedAt     time.Time
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Review struct {
+	ID               uuid.UUID
+	ProductID        uuid.UUID
+	UserID           uuid.UUID
+	Rating           int
+	Body             string
+	VerifiedPurchase bool
+	SellerReply      string
+	SellerRepliedAt  *time.Time
+	ReportCount      int
+	Hidden           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+type SellerProfile struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type SellerCategory struct {
+	Type         string
+	ProductCount int
+}
+
+type Storefront struct {
+	Seller        SellerProfile
+	ProductCount  int
+	RatingAverage float64
+	RatingCount   int
+	Categories    []SellerCategory
+	Featured      []Product
+	NewArrivals   []Product
+}
@@ -3,10 +3,32 @@ package gateways
 import (
 	"context"
 
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/messaging"
 )
 
 type EventPublisher interface {
 	PublishOrderCreated(ctx context.Context, event models.OrderCreatedEvent) error
 	// PublishOrderCanceled (coming soon)
 }
+
+type NotificationSender interface {
+	Send(ctx context.Context, payload messaging.NotificationPayload)
+}
+
+// AnalyticsRecorder captures storefront funnel events. Implementations must not fail the caller's request.
+type AnalyticsRecorder interface {
+	RecordProductView(ctx context.Context, productID uuid.UUID, viewerKey string)
+	RecordAddToCart(ctx context.Context, productID uuid.UUID)
+	RecordSale(ctx context.Context, productID uuid.UUID, quantity int)
+}
+
+// SellerDirectory resolves seller display data from the account service. Lookups degrade rather
+// than fail when the account service is unavailable, since seller names are cosmetic.
+type SellerDirectory interface {
+	GetSellerProfile(ctx context.Context, sellerID uuid.UUID) (*entities.SellerProfile, error)
+	GetSellerProfiles(ctx context.Context, sellerIDs []uuid.UUID) map[uuid.UUID]entities.SellerProfile
+}
@@ -2,14 +2,14 @@ package grpc
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
 
 	productpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/product"
@@ -18,11 +18,13 @@ import (
 type ProductServer struct {
 	productpb.UnimplementedProductServiceServer
 	ProductSvc services.ProductService
+	log        *logrus.Logger
 }
 
-func NewProductServer(productSvc services.ProductService) *ProductServer {
+func NewProductServer(productSvc services.ProductService, log *logrus.Logger) *ProductServer {
 	return &ProductServer{
 		ProductSvc: productSvc,
+		log:        log,
 	}
 }
 
@@ -32,14 +34,14 @@ func (s *ProductServer) GetProducts(ctx context.Context, req *productpb.GetProdu
 	for _, idStr := range req.GetIds() {
 		parsedID, err := uuid.Parse(idStr)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "format Product ID '%s' tidak valid", idStr)
+			return nil, apperrors.GRPCStatus(apperrors.Invalid("ids", "uuid", fmt.Sprintf("'%s' is not a valid UUID", idStr)))
 		}
 		ids = append(ids, parsedID)
 	}
 
 	dbProducts, err := s.ProductSvc.GetProductByIDs(ctx, ids)
 	if err != nil {
-		return nil, err
+		return nil, s.toStatus(ctx, err)
 	}
 
 	var protoProducts []*productpb.Product
@@ -61,10 +63,7 @@ func (s *ProductServer) GetProducts(ctx context.Context, req *productpb.GetProdu
 func (s *ProductServer) DecreaseStock(ctx context.Context, req *productpb.DecreaseStockRequest) (*productpb.DecreaseStockResponse, error) {
 	updatedProducts, err := s.ProductSvc.DecreaseStock(ctx, req.GetItems())
 	if err != nil {
-		if errors.Is(err, apperrors.ErrProductOutOfStock) {
-			return nil, status.Errorf(codes.FailedPrecondition, "product out of stock: %v", err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "failed to decrease stock: %v", err)
+		return nil, s.toStatus(ctx, err)
 	}
 
 	pbProducts := make([]*productpb.Product, len(updatedProducts))
@@ -89,7 +88,7 @@ func (s *ProductServer) DecreaseStock(ctx context.Context, req *productpb.Decrea
 func (s *ProductServer) IncreaseStock(ctx context.Context, req *productpb.IncreaseStockRequest) (*productpb.IncreaseStockResponse, error) {
 	updatedProducts, err := s.ProductSvc.IncreaseStock(ctx, req.GetItems())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to increase stock: %v", err)
+		return nil, s.toStatus(ctx, err)
 	}
 
 	pbProducts := make([]*productpb.Product, len(updatedProducts))
@@ -110,3 +109,14 @@ func (s *ProductServer) IncreaseStock(ctx context.Context, req *productpb.Increa
 		Products: pbProducts,
 	}, nil
 }
+
+// ------- HELPERS -------
+
+// toStatus maps err to a gRPC status, logging internal failures since their cause is not sent to the caller.
+func (s *ProductServer) toStatus(ctx context.Context, err error) error {
+	if apperrors.From(err).Kind == apperrors.KindInternal {
+		logctx.From(ctx, s.log).WithError(err).Error("gRPC request failed")
+	}
+
+	return apperrors.GRPCStatus(err)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type AnalyticsHandler struct {
+	AnalyticsSvc services.AnalyticsService
+	log          *logrus.Logger
+}
+
+func NewAnalyticsHandler(
+	analyticsSvc services.AnalyticsService,
+	log *logrus.Logger,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		AnalyticsSvc: analyticsSvc,
+		log:          log,
+	}
+}
+
+func (h *AnalyticsHandler) GetShopAnalytics() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		from, to, err := getDateRangeParams(c)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.AnalyticsSvc.GetShopAnalytics(ctx, sellerID, from, to)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgAnalyticsRetrieved, toShopAnalyticsResponse(res))
+	}
+}
+
+func (h *AnalyticsHandler) GetProductAnalytics() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		from, to, err := getDateRangeParams(c)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.AnalyticsSvc.GetProductAnalytics(ctx, productID, sellerID, from, to)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgAnalyticsRetrieved, toProductAnalyticsResponse(res))
+	}
+}
+
+// ------- HELPERS -------
+
+func toShopAnalyticsResponse(analytics *entities.ShopAnalytics) *models.ShopAnalyticsResponse {
+	products := make([]models.ProductAnalyticsResponse, 0, len(analytics.Products))
+	for i := range analytics.Products {
+		products = append(products, *toProductAnalyticsResponse(&analytics.Products[i]))
+	}
+
+	return &models.ShopAnalyticsResponse{
+		SellerID: analytics.SellerID,
+		From:     analytics.From.Format(dateParamLayout),
+		To:       analytics.To.Format(dateParamLayout),
+		Funnel:   toFunnelResponse(analytics.Funnel),
+		Series:   toDailyStatResponseList(analytics.Series),
+		Products: products,
+	}
+}
+
+func toProductAnalyticsResponse(analytics *entities.ProductAnalytics) *models.ProductAnalyticsResponse {
+	res := &models.ProductAnalyticsResponse{
+		ProductID:   analytics.ProductID,
+		ProductName: analytics.ProductName,
+		Funnel:      toFunnelResponse(analytics.Funnel),
+	}
+
+	if analytics.Series != nil {
+		res.Series = toDailyStatResponseList(analytics.Series)
+	}
+
+	return res
+}
+
+func toFunnelResponse(funnel entities.Funnel) models.FunnelResponse {
+	return models.FunnelResponse{
+		Views:           funnel.Views,
+		UniqueViewers:   funnel.UniqueViewers,
+		AddToCarts:      funnel.AddToCarts,
+		Orders:          funnel.Orders,
+		UnitsSold:       funnel.UnitsSold,
+		ViewToCartRate:  funnel.ViewToCartRate,
+		CartToOrderRate: funnel.CartToOrderRate,
+		ViewToOrderRate: funnel.ViewToOrderRate,
+	}
+}
+
+func toDailyStatResponseList(series []entities.DailyStat) []models.DailyStatResponse {
+	responses := make([]models.DailyStatResponse, 0, len(series))
+
+	for _, day := range series {
+		responses = append(responses, models.DailyStatResponse{
+			Date:          day.Date.Format(dateParamLayout),
+			Views:         day.Views,
+			UniqueViewers: day.UniqueViewers,
+			AddToCarts:    day.AddToCarts,
+			Orders:        day.Orders,
+			UnitsSold:     day.UnitsSold,
+		})
+	}
+
+	return responses
+}
@@ -34,21 +34,21 @@ func (h *CartHandler) AddToCart() echo.HandlerFunc {
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, errors.ErrInvalidUserSession)
+			return respondError(c, errors.ErrInvalidUserSession)
 		}
 
 		productID, err := getIDFromPathParam(c, "product_id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		var req models.CartRequest
 		if err := c.Bind(&req); err != nil {
-			return respondError(c, http.StatusBadRequest, errors.ErrInvalidRequestPayload)
+			return respondError(c, errors.ErrInvalidRequestPayload)
 		}
 
 		if err := h.CartSvc.AddItemToCart(ctx, userID, productID, &req); err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 		return respondSuccess(c, http.StatusOK, MsgCartCreated, nil)
 	}
@@ -56,17 +56,16 @@ func (h *CartHandler) AddToCart() echo.HandlerFunc {
 
 func (h *CartHandler) GetCartItemsByUserID() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		logrus.Info("request GetCartItemsByUserID")
 		ctx := c.Request().Context()
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, errors.ErrInvalidUserSession)
+			return respondError(c, errors.ErrInvalidUserSession)
 		}
 
 		res, err := h.CartSvc.GetCartItemsByUserID(ctx, userID)
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgCartRetrieved, toCartResponse(res))
@@ -79,27 +78,23 @@ func (h *CartHandler) UpdateCartItem() echo.HandlerFunc {
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, errors.ErrInvalidUserSession)
+			return respondError(c, errors.ErrInvalidUserSession)
 		}
 
 		productIDStr := c.Param("product_id")
 		productID, err := uuid.Parse(productIDStr)
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, errors.ErrInvalidRequestPayload)
+			return respondError(c, errors.ErrInvalidRequestPayload)
 		}
 
 		var req models.UpdateCartRequest
 		if err := c.Bind(&req); err != nil {
-			return respondError(c, http.StatusBadRequest, errors.ErrInvalidRequestPayload)
+			return respondError(c, errors.ErrInvalidRequestPayload)
 		}
 
-		logger := h.log.WithFields(logrus.Fields{"user_id": userID, "product_id": productID, "new_quantity": req.Quantity})
-		logger.Info("Receiving UpdateCartItem requests")
-
 		err = h.CartSvc.UpdateItem(ctx, userID, productID, req.Quantity, req.Description)
 		if err != nil {
-			logger.WithError(err).Error("Error dari service saat memperbarui item keranjang")
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgCartUpdated, nil)
@@ -112,17 +107,17 @@ func (h *CartHandler) RemoveFromCart() echo.HandlerFunc {
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, errors.ErrInvalidUserSession)
+			return respondError(c, errors.ErrInvalidUserSession)
 		}
 
 		productID, err := getIDFromPathParam(c, "product_id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		err = h.CartSvc.RemoveItemFromCart(ctx, userID, productID)
 		if err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgCartDeleted, nil)
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ConfigHandler shows operators the settings the service is actually running with. Secrets are
+// redacted before they reach the handler.
+type ConfigHandler struct {
+	settings map[string]string
+}
+
+func NewConfigHandler(settings map[string]string) *ConfigHandler {
+	return &ConfigHandler{settings: settings}
+}
+
+func (h *ConfigHandler) GetConfig() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return respondSuccess(c, http.StatusOK, MsgConfigRetrieved, h.settings)
+	}
+}
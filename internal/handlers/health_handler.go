@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/health"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+// HealthHandler serves orchestrator probes. Responses are bare JSON, not the usual envelope,
+// so probes and dashboards can read them directly. They are public, so check errors, which can
+// name hosts and credentials, only go to the log.
+type HealthHandler struct {
+	Checker   *health.Checker
+	startedAt time.Time
+	log       *logrus.Logger
+}
+
+func NewHealthHandler(checker *health.Checker, log *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		Checker:   checker,
+		startedAt: time.Now(),
+		log:       log,
+	}
+}
+
+// Liveness only says the process can serve HTTP; dependencies are deliberately not checked so
+// an outage elsewhere does not get every replica restarted.
+func (h *HealthHandler) Liveness() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, models.HealthResponse{
+			Status: string(health.StatusUp),
+			Uptime: time.Since(h.startedAt).Round(time.Second).String(),
+		})
+	}
+}
+
+func (h *HealthHandler) Readiness() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		report := h.Checker.Check(ctx)
+
+		for _, res := range report.Checks {
+			if res.Error != "" {
+				logctx.From(ctx, h.log).WithFields(logrus.Fields{
+					"check":          res.Name,
+					"classification": res.Classification,
+					"error":          res.Error,
+				}).Warn("Readiness check failed")
+			}
+		}
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+
+		return c.JSON(status, toHealthResponse(report))
+	}
+}
+
+// ------- HELPERS -------
+
+func toHealthResponse(report *health.Report) models.HealthResponse {
+	checks := make(map[string]models.CheckResult, len(report.Checks))
+	for _, res := range report.Checks {
+		checks[res.Name] = models.CheckResult{
+			Status:         string(res.Status),
+			Classification: string(res.Classification),
+			LatencyMs:      float64(res.Latency.Microseconds()) / 1000,
+		}
+	}
+
+	return models.HealthResponse{
+		Status:    string(report.Status),
+		CheckedAt: report.CheckedAt.UTC().Format(time.RFC3339),
+		Checks:    checks,
+	}
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -37,14 +41,9 @@ func getRoleFromContext(c echo.Context) (string, error) {
 }
 
 func getIDFromPathParam(c echo.Context, key string) (uuid.UUID, error) {
-	val := c.Param(key)
-	if val == "" || !helpers.IsValidUUID(val) {
-		return uuid.Nil, errors.ErrInvalidRequestPayload
-	}
-
-	res, err := helpers.StringToUUID(val)
+	res, err := helpers.StringToUUID(c.Param(key))
 	if err != nil {
-		return uuid.Nil, err
+		return uuid.Nil, errors.Invalid(key, "uuid", "must be a valid UUID")
 	}
 
 	return res, nil
@@ -53,8 +52,73 @@ func getIDFromPathParam(c echo.Context, key string) (uuid.UUID, error) {
 func getFromPathParam(c echo.Context, key string) (string, error) {
 	val := c.Param(key)
 	if val == "" {
-		return "", errors.ErrInvalidRequestPayload
+		return "", errors.Invalid(key, "required", "is required")
 	}
 
 	return val, nil
 }
+
+const (
+	defaultPage    = 1
+	defaultPerPage = 10
+	maxPerPage     = 100
+)
+
+// getPaginationParams reads ?page and ?per_page, falling back to sane defaults for missing or invalid values
+// and clamping both to their upper bounds.
+func getPaginationParams(c echo.Context) (int, int) {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+	if page > services.MaxPage {
+		page = services.MaxPage
+	}
+
+	perPage, err := strconv.Atoi(c.QueryParam("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	return page, perPage
+}
+
+const (
+	dateParamLayout  = "2006-01-02"
+	defaultRangeDays = 30
+)
+
+// getDateRangeParams reads ?from and ?to as UTC dates, defaulting to the last 30 days up to today.
+func getDateRangeParams(c echo.Context) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if val := c.QueryParam("to"); val != "" {
+		parsed, err := time.Parse(dateParamLayout, val)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Invalid("to", "date", "must be formatted as YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultRangeDays - 1))
+	if val := c.QueryParam("from"); val != "" {
+		parsed, err := time.Parse(dateParamLayout, val)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Invalid("from", "date", "must be formatted as YYYY-MM-DD")
+		}
+		from = parsed
+	}
+
+	return from, to, nil
+}
+
+// viewerKey identifies a viewer for unique-view counting: the user ID when authenticated, otherwise the client IP.
+func viewerKey(c echo.Context) string {
+	if userID, err := getUserIDFromContext(c); err == nil {
+		return userID.String()
+	}
+
+	return c.RealIP()
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type ModerationHandler struct {
+	ModerationSvc services.ModerationService
+	log           *logrus.Logger
+}
+
+func NewModerationHandler(
+	moderationSvc services.ModerationService,
+	log *logrus.Logger,
+) *ModerationHandler {
+	return &ModerationHandler{
+		ModerationSvc: moderationSvc,
+		log:           log,
+	}
+}
+
+func (h *ModerationHandler) SubmitProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		role, err := getRoleFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.ModerationSvc.SubmitForReview(ctx, productID, sellerID, role)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductSubmitted, toProductResponse(res))
+	}
+}
+
+func (h *ModerationHandler) ArchiveProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		role, err := getRoleFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.ModerationSvc.ArchiveProduct(ctx, productID, sellerID, role)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductArchived, toProductResponse(res))
+	}
+}
+
+func (h *ModerationHandler) GetProductsByStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		status := entities.ProductStatus(c.QueryParam("status"))
+		if status == "" {
+			status = entities.ProductStatusPendingReview
+		}
+
+		res, err := h.ModerationSvc.GetProductsByStatus(ctx, status)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
+	}
+}
+
+func (h *ModerationHandler) ApproveProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		adminID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.ModerationSvc.ApproveProduct(ctx, productID, adminID)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductApproved, toProductResponse(res))
+	}
+}
+
+func (h *ModerationHandler) RejectProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		adminID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ModerationRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.ModerationSvc.RejectProduct(ctx, productID, adminID, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductRejected, toProductResponse(res))
+	}
+}
+
+func (h *ModerationHandler) SuspendProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		adminID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ModerationRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.ModerationSvc.SuspendProduct(ctx, productID, adminID, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductSuspended, toProductResponse(res))
+	}
+}
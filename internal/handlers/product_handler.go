@@ -7,6 +7,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
 	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
@@ -15,15 +16,18 @@ import (
 
 type ProductHandler struct {
 	ProductSvc services.ProductService
+	Analytics  gateways.AnalyticsRecorder
 	log        *logrus.Logger
 }
 
 func NewProductHandler(
 	productSvc services.ProductService,
+	analytics gateways.AnalyticsRecorder,
 	log *logrus.Logger,
 ) *ProductHandler {
 	return &ProductHandler{
 		ProductSvc: productSvc,
+		Analytics:  analytics,
 		log:        log,
 	}
 }
@@ -34,17 +38,17 @@ func (p *ProductHandler) CreateProduct() echo.HandlerFunc {
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, apperrors.ErrInvalidUserSession)
+			return respondError(c, apperrors.ErrInvalidUserSession)
 		}
 
 		var req models.ProductRequest
 		if err := c.Bind(&req); err != nil {
-			return respondError(c, http.StatusBadRequest, apperrors.ErrInvalidRequestPayload)
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
 		}
 
 		res, err := p.ProductSvc.CreateProduct(ctx, userID, &req)
 		if err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusCreated, MsgProductCreated, toProductResponse(res))
@@ -55,9 +59,9 @@ func (p *ProductHandler) GetAllProducts() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
-		res, err := p.ProductSvc.GetAllProducts(ctx)
+		res, err := p.ProductSvc.GetAllProducts(ctx, c.QueryParam("sort"))
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
@@ -70,12 +74,12 @@ func (p *ProductHandler) GetProductsByName() echo.HandlerFunc {
 
 		productName, err := getFromPathParam(c, "name")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		res, err := p.ProductSvc.GetProductsByName(ctx, productName)
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
@@ -88,12 +92,12 @@ func (p *ProductHandler) GetProductsByType() echo.HandlerFunc {
 
 		productType, err := getFromPathParam(c, "type")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		res, err := p.ProductSvc.GetProductsByType(ctx, productType)
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
@@ -106,14 +110,16 @@ func (p *ProductHandler) GetProductByID() echo.HandlerFunc {
 
 		productID, err := getIDFromPathParam(c, "id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		res, err := p.ProductSvc.GetProductByID(ctx, productID)
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
 		}
 
+		p.Analytics.RecordProductView(ctx, productID, viewerKey(c))
+
 		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponse(res))
 	}
 }
@@ -124,12 +130,30 @@ func (p *ProductHandler) GetProductsBySellerID() echo.HandlerFunc {
 
 		sellerID, err := getIDFromPathParam(c, "seller_id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		res, err := p.ProductSvc.GetProductsBySellerID(ctx, sellerID)
 		if err != nil {
-			return handleGetError(c, err)
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
+	}
+}
+
+func (p *ProductHandler) GetMyProducts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		res, err := p.ProductSvc.GetMyProducts(ctx, sellerID)
+		if err != nil {
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res))
@@ -142,27 +166,27 @@ func (p *ProductHandler) UpdateProduct() echo.HandlerFunc {
 
 		userID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, apperrors.ErrInvalidUserSession)
+			return respondError(c, apperrors.ErrInvalidUserSession)
 		}
 
 		role, err := getRoleFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, apperrors.ErrInvalidUserSession)
+			return respondError(c, apperrors.ErrInvalidUserSession)
 		}
 
 		productID, err := getIDFromPathParam(c, "product_id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		var productData models.ProductRequest
 		if err := c.Bind(&productData); err != nil {
-			return respondError(c, http.StatusBadRequest, apperrors.ErrInvalidRequestPayload)
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
 		}
 
 		res, err := p.ProductSvc.UpdateProduct(ctx, &productData, productID, userID, role)
 		if err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductUpdated, toProductResponse(res))
@@ -176,22 +200,22 @@ func (p *ProductHandler) DeleteProduct() echo.HandlerFunc {
 
 		sellerID, err := getUserIDFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, apperrors.ErrInvalidUserSession)
+			return respondError(c, apperrors.ErrInvalidUserSession)
 		}
 
 		role, err := getRoleFromContext(c)
 		if err != nil {
-			return respondError(c, http.StatusUnauthorized, apperrors.ErrInvalidUserSession)
+			return respondError(c, apperrors.ErrInvalidUserSession)
 		}
 
 		productID, err := getIDFromPathParam(c, "product_id")
 		if err != nil {
-			return respondError(c, http.StatusBadRequest, err)
+			return respondError(c, err)
 		}
 
 		res, err := p.ProductSvc.DeleteProduct(ctx, productID, sellerID, role)
 		if err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, MsgProductDeleted, toProductResponse(res))
@@ -204,7 +228,7 @@ func (p *ProductHandler) ClearProductCaches() echo.HandlerFunc {
 
 		err := p.ProductSvc.ResetAllProductCaches(ctx)
 		if err != nil {
-			return handleOperationError(c, err)
+			return respondError(c, err)
 		}
 
 		return respondSuccess(c, http.StatusOK, apperrors.MsgProductCacheCleared, nil)
@@ -214,16 +238,20 @@ func (p *ProductHandler) ClearProductCaches() echo.HandlerFunc {
 // ------- HELPERS -------
 func toProductResponse(product *entities.Product) *models.ProductResponse {
 	return &models.ProductResponse{
-		ID:          product.ID,
-		SellerID:    product.SellerID,
-		Name:        product.Name,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		Discount:    product.Discount,
-		Type:        product.Type,
-		Description: product.Description,
-		CreatedAt:   product.CreatedAt.Format(helpers.LAYOUTFORMAT),
-		UpdatedAt:   product.UpdatedAt.Format(helpers.LAYOUTFORMAT),
+		ID:            product.ID,
+		SellerID:      product.SellerID,
+		Name:          product.Name,
+		Price:         product.Price,
+		Stock:         product.Stock,
+		Discount:      product.Discount,
+		Type:          product.Type,
+		Description:   product.Description,
+		Status:        string(product.Status),
+		StatusReason:  product.StatusReason,
+		RatingAverage: product.RatingAverage,
+		RatingCount:   product.RatingCount,
+		CreatedAt:     product.CreatedAt.Format(helpers.LAYOUTFORMAT),
+		UpdatedAt:     product.UpdatedAt.Format(helpers.LAYOUTFORMAT),
 	}
 }
 
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type QuestionHandler struct {
+	QuestionSvc services.QuestionService
+	log         *logrus.Logger
+}
+
+func NewQuestionHandler(
+	questionSvc services.QuestionService,
+	log *logrus.Logger,
+) *QuestionHandler {
+	return &QuestionHandler{
+		QuestionSvc: questionSvc,
+		log:         log,
+	}
+}
+
+func (h *QuestionHandler) GetProductQuestions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		productID, err := getIDFromPathParam(c, "id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		page, perPage := getPaginationParams(c)
+
+		res, total, err := h.QuestionSvc.GetProductQuestions(ctx, productID, page, perPage)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondPaginated(c, http.StatusOK, MsgQuestionRetrieved, toQuestionResponseList(res), page, perPage, total)
+	}
+}
+
+func (h *QuestionHandler) AskQuestion() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.QuestionRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.QuestionSvc.AskQuestion(ctx, userID, productID, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusCreated, MsgQuestionCreated, toQuestionResponse(res))
+	}
+}
+
+func (h *QuestionHandler) AnswerQuestion() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		responderID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		role, err := getRoleFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		questionID, err := getIDFromPathParam(c, "question_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.AnswerRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.QuestionSvc.AnswerQuestion(ctx, questionID, responderID, role, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusCreated, MsgAnswerCreated, toAnswerResponse(res))
+	}
+}
+
+func (h *QuestionHandler) GetQuestionsByStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		status := entities.ContentStatus(c.QueryParam("status"))
+		if status == "" {
+			status = entities.ContentStatusFlagged
+		}
+
+		page, perPage := getPaginationParams(c)
+
+		res, total, err := h.QuestionSvc.GetQuestionsByStatus(ctx, status, page, perPage)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondPaginated(c, http.StatusOK, MsgQuestionRetrieved, toQuestionResponseList(res), page, perPage, total)
+	}
+}
+
+func (h *QuestionHandler) SetQuestionStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		questionID, err := getIDFromPathParam(c, "question_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ContentStatusRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.QuestionSvc.SetQuestionStatus(ctx, questionID, entities.ContentStatus(req.Status))
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgQuestionStatusUpdated, toQuestionResponse(res))
+	}
+}
+
+func (h *QuestionHandler) SetAnswerStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		answerID, err := getIDFromPathParam(c, "answer_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ContentStatusRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.QuestionSvc.SetAnswerStatus(ctx, answerID, entities.ContentStatus(req.Status))
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgAnswerStatusUpdated, toAnswerResponse(res))
+	}
+}
+
+// ------- HELPERS -------
+
+func toQuestionResponse(question *entities.Question) *models.QuestionResponse {
+	answers := make([]models.AnswerResponse, 0, len(question.Answers))
+	for i := range question.Answers {
+		answers = append(answers, *toAnswerResponse(&question.Answers[i]))
+	}
+
+	return &models.QuestionResponse{
+		ID:        question.ID,
+		ProductID: question.ProductID,
+		AskerID:   question.AskerID,
+		Body:      question.Body,
+		Status:    string(question.Status),
+		Answers:   answers,
+		CreatedAt: question.CreatedAt.Format(helpers.LAYOUTFORMAT),
+	}
+}
+
+func toQuestionResponseList(questions []entities.Question) []*models.QuestionResponse {
+	responses := make([]*models.QuestionResponse, 0, len(questions))
+
+	for i := range questions {
+		responses = append(responses, toQuestionResponse(&questions[i]))
+	}
+
+	return responses
+}
+
+func toAnswerResponse(answer *entities.Answer) *models.AnswerResponse {
+	return &models.AnswerResponse{
+		ID:            answer.ID,
+		QuestionID:    answer.QuestionID,
+		ResponderID:   answer.ResponderID,
+		ResponderRole: answer.ResponderRole,
+		Body:          answer.Body,
+		Status:        string(answer.Status),
+		CreatedAt:     answer.CreatedAt.Format(helpers.LAYOUTFORMAT),
+	}
+}
@@ -1,12 +1,8 @@
 package handlers
 
 import (
-	"errors"
-	"fmt"
-	"net/http"
-
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/delivery/http/problem"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
-	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
 	"github.com/labstack/echo/v4"
 )
 
@@ -16,6 +12,31 @@ const (
 	MsgProductUpdated   = "Product updated successfully"
 	MsgProductDeleted   = "Product deleted successfully"
 
+	MsgProductSubmitted = "Product submitted successfully"
+	MsgProductArchived  = "Product archived successfully"
+	MsgProductApproved  = "Product approved successfully"
+	MsgProductRejected  = "Product rejected successfully"
+	MsgProductSuspended = "Product suspended successfully"
+
+	MsgReviewRetrieved = "Reviews retrieved successfully"
+	MsgReviewCreated   = "Review created successfully"
+	MsgReviewUpdated   = "Review updated successfully"
+	MsgReviewDeleted   = "Review deleted successfully"
+	MsgReviewReplied   = "Review reply saved successfully"
+	MsgReviewReported  = "Review reported successfully"
+
+	MsgQuestionRetrieved     = "Questions retrieved successfully"
+	MsgQuestionCreated       = "Question posted successfully"
+	MsgAnswerCreated         = "Answer posted successfully"
+	MsgQuestionStatusUpdated = "Question status updated successfully"
+	MsgAnswerStatusUpdated   = "Answer status updated successfully"
+
+	MsgStorefrontRetrieved = "Storefront retrieved successfully"
+
+	MsgAnalyticsRetrieved = "Analytics retrieved successfully"
+
+	MsgConfigRetrieved = "Configuration retrieved successfully"
+
 	MsgFailedToRetrieveProduct = "Failed to retrieve product"
 	MsgFailedToCreateProduct   = "Failed to create product"
 	MsgFailedToUpdateProduct   = "Failed to update product"
@@ -51,44 +72,24 @@ func respondSuccess(c echo.Context, status int, message string, data interface{}
 	})
 }
 
-func respondError(c echo.Context, status int, err error) error {
-	return c.JSON(status, models.ErrorResponse{
-		Error: err.Error(),
-	})
-}
-
-func handleGetError(c echo.Context, err error) error {
-	switch {
-	case errors.Is(err, apperrors.ErrInvalidUserInput),
-		errors.Is(err, apperrors.ErrInvalidCartOperation):
-		return respondError(c, http.StatusBadRequest, err)
-
-	case errors.Is(err, apperrors.ErrInsufficientStock),
-		errors.Is(err, apperrors.ErrCartAlreadyCheckedOut):
-		return respondError(c, http.StatusForbidden, err)
-
-	case errors.Is(err, apperrors.ErrInternalServerError):
-		return respondError(c, http.StatusInternalServerError, err)
-
-	default:
-		return respondError(c, http.StatusInternalServerError, fmt.Errorf("%w: %s", apperrors.ErrInternalServerError, err))
+func respondPaginated(c echo.Context, status int, message string, data interface{}, page, perPage, totalItems int) error {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (totalItems + perPage - 1) / perPage
 	}
+
+	return c.JSON(status, models.PaginatedResponse{
+		Message: message,
+		Data:    data,
+		Paging: models.PagingInfo{
+			Page:       page,
+			PerPage:    perPage,
+			TotalItems: totalItems,
+			TotalPages: totalPages,
+		},
+	})
 }
 
-func handleOperationError(c echo.Context, err error) error {
-	switch {
-	case errors.Is(err, apperrors.ErrProductNotBelongToSeller),
-		errors.Is(err, apperrors.ErrInvalidUserInput),
-		errors.Is(err, apperrors.ErrInvalidCartOperation):
-		return respondError(c, http.StatusForbidden, err)
-
-	case err.Error() == apperrors.ErrInvalidProductUpdatePayload.Error(),
-		errors.Is(err, apperrors.ErrInsufficientStock),
-		errors.Is(err, apperrors.ErrCartAlreadyCheckedOut):
-		return respondError(c, http.StatusBadRequest, apperrors.ErrInvalidRequestPayload)
-
-	default:
-		c.Logger().Errorf("%s: %v", apperrors.ErrInternalServerError, err)
-		return respondError(c, http.StatusInternalServerError, fmt.Errorf("internal server error"))
-	}
+func respondError(c echo.Context, err error) error {
+	return problem.Respond(c, err)
 }
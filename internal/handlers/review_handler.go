@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type ReviewHandler struct {
+	ReviewSvc services.ReviewService
+	log       *logrus.Logger
+}
+
+func NewReviewHandler(
+	reviewSvc services.ReviewService,
+	log *logrus.Logger,
+) *ReviewHandler {
+	return &ReviewHandler{
+		ReviewSvc: reviewSvc,
+		log:       log,
+	}
+}
+
+func (h *ReviewHandler) GetProductReviews() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		productID, err := getIDFromPathParam(c, "id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		page, perPage := getPaginationParams(c)
+
+		res, total, err := h.ReviewSvc.GetProductReviews(ctx, productID, c.QueryParam("sort"), page, perPage)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondPaginated(c, http.StatusOK, MsgReviewRetrieved, toReviewResponseList(res), page, perPage, total)
+	}
+}
+
+func (h *ReviewHandler) CreateReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ReviewRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.ReviewSvc.CreateReview(ctx, userID, productID, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusCreated, MsgReviewCreated, toReviewResponse(res))
+	}
+}
+
+func (h *ReviewHandler) UpdateReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ReviewRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.ReviewSvc.UpdateReview(ctx, userID, productID, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgReviewUpdated, toReviewResponse(res))
+	}
+}
+
+func (h *ReviewHandler) DeleteReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		productID, err := getIDFromPathParam(c, "product_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		if err := h.ReviewSvc.DeleteReview(ctx, userID, productID); err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgReviewDeleted, nil)
+	}
+}
+
+func (h *ReviewHandler) ReplyToReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		role, err := getRoleFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		reviewID, err := getIDFromPathParam(c, "review_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ReviewReplyRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		res, err := h.ReviewSvc.ReplyToReview(ctx, reviewID, sellerID, role, &req)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgReviewReplied, toReviewResponse(res))
+	}
+}
+
+func (h *ReviewHandler) ReportReview() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userID, err := getUserIDFromContext(c)
+		if err != nil {
+			return respondError(c, apperrors.ErrInvalidUserSession)
+		}
+
+		reviewID, err := getIDFromPathParam(c, "review_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		var req models.ReviewReportRequest
+		if err := c.Bind(&req); err != nil {
+			return respondError(c, apperrors.ErrInvalidRequestPayload)
+		}
+
+		if err := h.ReviewSvc.ReportReview(ctx, reviewID, userID, &req); err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgReviewReported, nil)
+	}
+}
+
+// ------- HELPERS -------
+
+func toReviewResponse(review *entities.Review) *models.ReviewResponse {
+	res := &models.ReviewResponse{
+		ID:               review.ID,
+		ProductID:        review.ProductID,
+		UserID:           review.UserID,
+		Rating:           review.Rating,
+		Body:             review.Body,
+		VerifiedPurchase: review.VerifiedPurchase,
+		SellerReply:      review.SellerReply,
+		CreatedAt:        review.CreatedAt.Format(helpers.LAYOUTFORMAT),
+		UpdatedAt:        review.UpdatedAt.Format(helpers.LAYOUTFORMAT),
+	}
+
+	if review.SellerRepliedAt != nil {
+		res.SellerRepliedAt = review.SellerRepliedAt.Format(helpers.LAYOUTFORMAT)
+	}
+
+	return res
+}
+
+func toReviewResponseList(reviews []entities.Review) []*models.ReviewResponse {
+	responses := make([]*models.ReviewResponse, 0, len(reviews))
+
+	for i := range reviews {
+		responses = append(responses, toReviewResponse(&reviews[i]))
+	}
+
+	return responses
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/services"
+)
+
+type StorefrontHandler struct {
+	StorefrontSvc services.StorefrontService
+	log           *logrus.Logger
+}
+
+func NewStorefrontHandler(
+	storefrontSvc services.StorefrontService,
+	log *logrus.Logger,
+) *StorefrontHandler {
+	return &StorefrontHandler{
+		StorefrontSvc: storefrontSvc,
+		log:           log,
+	}
+}
+
+func (h *StorefrontHandler) GetStorefront() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getIDFromPathParam(c, "seller_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		res, err := h.StorefrontSvc.GetStorefront(ctx, sellerID)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondSuccess(c, http.StatusOK, MsgStorefrontRetrieved, toStorefrontResponse(res))
+	}
+}
+
+func (h *StorefrontHandler) GetSellerProducts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		sellerID, err := getIDFromPathParam(c, "seller_id")
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		page, perPage := getPaginationParams(c)
+
+		res, total, err := h.StorefrontSvc.GetSellerProducts(ctx, sellerID, c.QueryParam("sort"), page, perPage)
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		return respondPaginated(c, http.StatusOK, MsgProductRetrieved, toProductResponseList(res), page, perPage, total)
+	}
+}
+
+// ------- HELPERS -------
+
+func toStorefrontResponse(storefront *entities.Storefront) *models.StorefrontResponse {
+	categories := make([]models.SellerCategoryResponse, 0, len(storefront.Categories))
+	for _, c := range storefront.Categories {
+		categories = append(categories, models.SellerCategoryResponse{
+			Type:         c.Type,
+			ProductCount: c.ProductCount,
+		})
+	}
+
+	return &models.StorefrontResponse{
+		Seller: models.SellerProfileResponse{
+			ID:   storefront.Seller.ID,
+			Name: storefront.Seller.Name,
+		},
+		ProductCount:  storefront.ProductCount,
+		RatingAverage: storefront.RatingAverage,
+		RatingCount:   storefront.RatingCount,
+		Categories:    categories,
+		Featured:      toProductResponseList(storefront.Featured),
+		NewArrivals:   toProductResponseList(storefront.NewArrivals),
+	}
+}
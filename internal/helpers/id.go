@@ -4,14 +4,8 @@ import (
 	"github.com/google/uuid"
 )
 
-/*
-GenerateNewUserID digunakan agar skalabilitas, desentralisasi, keamanan, dan fleksibilitas terjamin
-
-	skalabilitas -> Menghindari bottleneck saat data tubuh besar
-	desentralisasi -> mengurangi otonomi dan meningkatkan coupling antar komponen
-	global uniquesness without coordination -> tidak khawatir akan tabrakan ID
-	fleksibilitas -> memberikan ID yang dapat dibagikan dan dijamin unik di seluruh ekosistem, tanpa perlu khawatir tentang konflik ID dengan sistem eksternal
-*/
+// GenerateNewID returns a random UUID. UUIDs can be minted by any service without
+// coordination, avoid a central ID bottleneck and stay unique across the ecosystem.
 func GenerateNewID() uuid.UUID {
 	return uuid.New()
 }
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsCounterKey identifies one product's counters for one UTC day in Redis.
+type AnalyticsCounterKey struct {
+	Day       time.Time
+	ProductID uuid.UUID
+}
+
+func (k AnalyticsCounterKey) String() string {
+	return fmt.Sprintf("%s|%s", k.Day.Format("2006-01-02"), k.ProductID)
+}
+
+type AnalyticsCounters struct {
+	Views         int
+	UniqueViewers int
+	AddToCarts    int
+	Orders        int
+	UnitsSold     int
+}
+
+type FunnelResponse struct {
+	Views           int     `json:"views"`
+	UniqueViewers   int     `json:"unique_viewers"`
+	AddToCarts      int     `json:"add_to_carts"`
+	Orders          int     `json:"orders"`
+	UnitsSold       int     `json:"units_sold"`
+	ViewToCartRate  float64 `json:"view_to_cart_rate"`
+	CartToOrderRate float64 `json:"cart_to_order_rate"`
+	ViewToOrderRate float64 `json:"view_to_order_rate"`
+}
+
+type DailyStatResponse struct {
+	Date          string `json:"date"`
+	Views         int    `json:"views"`
+	UniqueViewers int    `json:"unique_viewers"`
+	AddToCarts    int    `json:"add_to_carts"`
+	Orders        int    `json:"orders"`
+	UnitsSold     int    `json:"units_sold"`
+}
+
+type ProductAnalyticsResponse struct {
+	ProductID   uuid.UUID           `json:"product_id"`
+	ProductName string              `json:"product_name"`
+	Funnel      FunnelResponse      `json:"funnel"`
+	Series      []DailyStatResponse `json:"series,omitempty"`
+}
+
+type ShopAnalyticsResponse struct {
+	SellerID uuid.UUID                  `json:"seller_id"`
+	From     string                     `json:"from"`
+	To       string                     `json:"to"`
+	Funnel   FunnelResponse             `json:"funnel"`
+	Series   []DailyStatResponse        `json:"series"`
+	Products []ProductAnalyticsResponse `json:"products"`
+}
@@ -1,10 +0,0 @@
-package models
-
-type Credential struct {
-	Host         string
-	Username     string
-	Password     string
-	DatabaseName string
-	Port         int
-	Schema       string
-}
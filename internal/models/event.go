@@ -10,3 +10,21 @@ type OrderCreatedEvent struct {
 	ProductIDs  []string       `json:"product_ids"`
 	Quantities  map[string]int `json:"quantities"`
 }
+
+type ProductStatusChangedEvent struct {
+	ProductID  string    `json:"product_id"`
+	SellerID   string    `json:"seller_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason,omitempty"`
+	ChangedBy  string    `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+type QuestionAnsweredEvent struct {
+	QuestionID  string `json:"question_id"`
+	ProductID   string `json:"product_id"`
+	AnswerID    string `json:"answer_id"`
+	ResponderID string `json:"responder_id"`
+	Answer      string `json:"answer"`
+}
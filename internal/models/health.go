@@ -0,0 +1,14 @@
+package models
+
+type HealthResponse struct {
+	Status    string                 `json:"status"`
+	CheckedAt string                 `json:"checked_at,omitempty"`
+	Uptime    string                 `json:"uptime,omitempty"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type CheckResult struct {
+	Status         string  `json:"status"`
+	Classification string  `json:"classification"`
+	LatencyMs      float64 `json:"latency_ms"`
+}
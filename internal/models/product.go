@@ -15,16 +15,24 @@ type ProductRequest struct {
 	Description string `json:"description"`
 }
 type ProductResponse struct {
-	ID          uuid.UUID `json:"id"`
-	SellerID    uuid.UUID `json:"seller_id"`
-	Name        string    `json:"name"`
-	Price       int       `json:"price"`
-	Stock       int       `json:"stock"`
-	Discount    int       `json:"discount"`
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	CreatedAt   string    `json:"created_at"`
-	UpdatedAt   string    `json:"updated_at"`
+	ID            uuid.UUID `json:"id"`
+	SellerID      uuid.UUID `json:"seller_id"`
+	Name          string    `json:"name"`
+	Price         int       `json:"price"`
+	Stock         int       `json:"stock"`
+	Discount      int       `json:"discount"`
+	Type          string    `json:"type"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	StatusReason  string    `json:"status_reason,omitempty"`
+	RatingAverage float64   `json:"rating_average"`
+	RatingCount   int       `json:"rating_count"`
+	CreatedAt     string    `json:"created_at"`
+	UpdatedAt     string    `json:"updated_at"`
+}
+
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
 }
 
 type ProductWithSeller struct {
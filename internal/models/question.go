@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type QuestionRequest struct {
+	Body string `json:"body" validate:"required,min=5,max=1000"`
+}
+
+type AnswerRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=2000"`
+}
+
+type ContentStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=visible flagged hidden"`
+}
+
+type QuestionResponse struct {
+	ID        uuid.UUID        `json:"id"`
+	ProductID uuid.UUID        `json:"product_id"`
+	AskerID   uuid.UUID        `json:"asker_id"`
+	Body      string           `json:"body"`
+	Status    string           `json:"status"`
+	Answers   []AnswerResponse `json:"answers"`
+	CreatedAt string           `json:"created_at"`
+}
+
+type AnswerResponse struct {
+	ID            uuid.UUID `json:"id"`
+	QuestionID    uuid.UUID `json:"question_id"`
+	ResponderID   uuid.UUID `json:"responder_id"`
+	ResponderRole string    `json:"responder_role"`
+	Body          string    `json:"body"`
+	Status        string    `json:"status"`
+	CreatedAt     string    `json:"created_at"`
+}
@@ -1,7 +1,31 @@
 package models
 
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error   string             `json:"error"`
+	Code    string             `json:"code,omitempty"`
+	Details []ErrorFieldDetail `json:"details,omitempty"`
+}
+
+type ErrorFieldDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ProblemDetails is an RFC 7807 error body. Code is an extension member carrying the stable error code.
+type ProblemDetails struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Code     string              `json:"code,omitempty"`
+	Errors   []ProblemFieldError `json:"errors,omitempty"`
+}
+
+type ProblemFieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
 }
 
 type SuccessResponse struct {
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body" validate:"max=2000"`
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" validate:"required,min=1,max=2000"`
+}
+
+type ReviewReportRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+type ReviewResponse struct {
+	ID               uuid.UUID `json:"id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Rating           int       `json:"rating"`
+	Body             string    `json:"body"`
+	VerifiedPurchase bool      `json:"verified_purchase"`
+	SellerReply      string    `json:"seller_reply,omitempty"`
+	SellerRepliedAt  string    `json:"seller_replied_at,omitempty"`
+	CreatedAt        string    `json:"created_at"`
+	UpdatedAt        string    `json:"updated_at"`
+}
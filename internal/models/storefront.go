@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type SellerProfileResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type SellerCategoryResponse struct {
+	Type         string `json:"type"`
+	ProductCount int    `json:"product_count"`
+}
+
+type StorefrontResponse struct {
+	Seller        SellerProfileResponse    `json:"seller"`
+	ProductCount  int                      `json:"product_count"`
+	RatingAverage float64                  `json:"rating_average"`
+	RatingCount   int                      `json:"rating_count"`
+	Categories    []SellerCategoryResponse `json:"categories"`
+	Featured      []*ProductResponse       `json:"featured"`
+	NewArrivals   []*ProductResponse       `json:"new_arrivals"`
+}
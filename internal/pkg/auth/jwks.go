@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+)
+
+var (
+	// ErrKeySetUnavailable means no signing keys have been loaded yet.
+	ErrKeySetUnavailable = errors.New("jwks: no signing keys loaded")
+	// ErrKeyNotFound means the token names a key the set does not contain, even after a refresh.
+	ErrKeyNotFound = errors.New("jwks: signing key not found")
+)
+
+const (
+	// minRefreshInterval throttles refreshes triggered by unknown key IDs, so a flood of forged
+	// kids cannot hammer the JWKS endpoint.
+	minRefreshInterval = 30 * time.Second
+	fetchTimeout       = 10 * time.Second
+	maxJWKSSize        = 1 << 20
+)
+
+// KeySet holds the account service's public signing keys by key ID. Keys are reloaded on an
+// interval and whenever a token names a key the set has not seen, which picks up rotations
+// before the next scheduled refresh.
+type KeySet struct {
+	url      string
+	file     string
+	interval time.Duration
+	client   *http.Client
+	log      *logrus.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastAttempt time.Time
+	lastErr     error
+
+	refreshMu sync.Mutex
+}
+
+// NewKeySet loads the JWKS from cfg.JWKSURL or cfg.JWKSFile. A failed initial load does not fail
+// startup: tokens cannot be verified locally until a later refresh succeeds.
+func NewKeySet(cfg *configs.AuthConfig, log *logrus.Logger) (*KeySet, error) {
+	if (cfg.JWKSURL == "") == (cfg.JWKSFile == "") {
+		return nil, fmt.Errorf("jwks: exactly one of AUTH_JWKS_URL and AUTH_JWKS_FILE must be set")
+	}
+
+	ks := &KeySet{
+		url:      cfg.JWKSURL,
+		file:     cfg.JWKSFile,
+		interval: cfg.JWKSRefreshInterval,
+		client:   &http.Client{Timeout: fetchTimeout},
+		log:      log,
+		keys:     map[string]crypto.PublicKey{},
+	}
+
+	if err := ks.refresh(context.Background(), true); err != nil {
+		log.WithField("source", ks.source()).WithError(err).Warn("JWKS is unavailable at startup, local token verification will fail until it loads")
+	}
+
+	return ks, nil
+}
+
+// Run refreshes the key set every interval until ctx is cancelled.
+func (ks *KeySet) Run(ctx context.Context) {
+	ticker := time.NewTicker(ks.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.refresh(ctx, true); err != nil {
+				ks.log.WithField("source", ks.source()).WithError(err).Warn("Failed to refresh JWKS, keeping the previous keys")
+			}
+		}
+	}
+}
+
+// Key returns the public key for kid. An empty kid is accepted only while the set holds a single key.
+func (ks *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, err := ks.lookup(kid); err == nil {
+		return key, nil
+	}
+
+	if kid != "" {
+		// Possibly a freshly rotated key; the refresh is throttled.
+		_ = ks.refresh(ctx, false)
+	}
+
+	return ks.lookup(kid)
+}
+
+// Check reports whether the set holds keys and the last refresh succeeded.
+func (ks *KeySet) Check(ctx context.Context) error {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if len(ks.keys) == 0 {
+		if ks.lastErr != nil {
+			return fmt.Errorf("%w: %v", ErrKeySetUnavailable, ks.lastErr)
+		}
+		return ErrKeySetUnavailable
+	}
+	if ks.lastErr != nil {
+		return fmt.Errorf("jwks: last refresh failed: %w", ks.lastErr)
+	}
+
+	return nil
+}
+
+// ------- HELPERS -------
+
+func (ks *KeySet) lookup(kid string) (crypto.PublicKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if len(ks.keys) == 0 {
+		return nil, ErrKeySetUnavailable
+	}
+
+	if kid == "" {
+		if len(ks.keys) == 1 {
+			for _, key := range ks.keys {
+				return key, nil
+			}
+		}
+		return nil, ErrKeyNotFound
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// refresh reloads the set. Unless forced, it is skipped when the previous attempt was recent.
+func (ks *KeySet) refresh(ctx context.Context, force bool) error {
+	ks.refreshMu.Lock()
+	defer ks.refreshMu.Unlock()
+
+	ks.mu.RLock()
+	recent := time.Since(ks.lastAttempt) < minRefreshInterval
+	lastErr := ks.lastErr
+	ks.mu.RUnlock()
+	if !force && recent {
+		return lastErr
+	}
+
+	keys, err := ks.load(ctx)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.lastAttempt = time.Now()
+	ks.lastErr = err
+	if err != nil {
+		return err
+	}
+
+	ks.keys = keys
+	return nil
+}
+
+func (ks *KeySet) load(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	raw, err := ks.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("jwks: decode: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			ks.log.WithField("kid", jwk.Kid).WithError(err).Warn("Skipping unusable JWKS key")
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks: %s contains no usable signing keys", ks.source())
+	}
+
+	return keys, nil
+}
+
+func (ks *KeySet) read(ctx context.Context) ([]byte, error) {
+	if ks.file != "" {
+		raw, err := os.ReadFile(ks.file)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: read file: %w", err)
+		}
+		return raw, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: build request: %w", err)
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: fetch: unexpected status %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("jwks: read body: %w", err)
+	}
+
+	return raw, nil
+}
+
+func (ks *KeySet) source() string {
+	if ks.file != "" {
+		return ks.file
+	}
+	return ks.url
+}
+
+// jsonWebKey covers the RSA and EC members of RFC 7517 keys.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("unsupported exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		if x.BitLen() > 256 || y.BitLen() > 256 {
+			return nil, fmt.Errorf("coordinates too large for %s", k.Crv)
+		}
+		// Reject points off the curve before they reach signature verification.
+		point := append([]byte{4}, append(x.FillBytes(make([]byte, 32)), y.FillBytes(make([]byte, 32))...)...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(raw), nil
+}
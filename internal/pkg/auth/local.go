@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+// localVerifier checks RS256/ES256 signatures against the key set without a network call. When
+// the key set cannot decide (not loaded, or an unknown kid after a refresh) it defers to fallback
+// if one is set, otherwise it fails.
+type localVerifier struct {
+	keys     *KeySet
+	parser   *jwt.Parser
+	fallback Verifier
+	log      *logrus.Logger
+}
+
+func newLocalVerifier(cfg *configs.AuthConfig, keys *KeySet, log *logrus.Logger) *localVerifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithAudience(cfg.Audiences...),
+		jwt.WithLeeway(cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+
+	return &localVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+		log:    log,
+	}
+}
+
+func (v *localVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	var tc tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &tc, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+
+	if errors.Is(err, ErrKeySetUnavailable) || errors.Is(err, ErrKeyNotFound) {
+		if v.fallback != nil {
+			logctx.From(ctx, v.log).WithError(err).Debug("Local token verification undecided, falling back to the account service")
+			return v.fallback.Verify(ctx, token)
+		}
+		if errors.Is(err, ErrKeySetUnavailable) {
+			err = apperrors.ErrAuthUnavailable.Wrap(err)
+			observe(ModeLocal, err)
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		err = tokenError(err)
+		observe(ModeLocal, err)
+		return nil, err
+	}
+
+	claims := tc.toClaims()
+	if claims.UserID == "" {
+		err = apperrors.ErrInvalidToken.Withf("token has no subject")
+		observe(ModeLocal, err)
+		return nil, err
+	}
+
+	observe(ModeLocal, nil)
+	return claims, nil
+}
+
+// ------- HELPERS -------
+
+func tokenError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return apperrors.ErrInvalidToken.Withf("token has expired").Wrap(err)
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return apperrors.ErrInvalidToken.Withf("invalid token audience").Wrap(err)
+	default:
+		return apperrors.ErrInvalidToken.Wrap(err)
+	}
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+// remoteVerifier asks the account service and caches its answer, positive or negative, under a
+// hash of the token. Audience, issuer and expiry are read from the unverified payload: they only
+// ever narrow what the account service accepted.
+type remoteVerifier struct {
+	client    *account.AuthClient
+	results   *cache.Cache[Claims]
+	timeout   time.Duration
+	audiences []string
+	issuer    string
+	clockSkew time.Duration
+	log       *logrus.Logger
+}
+
+func newRemoteVerifier(cfg *configs.AuthConfig, client *account.AuthClient, store *cache.Store, log *logrus.Logger) *remoteVerifier {
+	return &remoteVerifier{
+		client: client,
+		results: cache.New[Claims](store, cache.Options{
+			Namespace:   "auth",
+			TTL:         cfg.RemoteCacheTTL,
+			NegativeTTL: cfg.RemoteNegativeTTL,
+			NotFound:    apperrors.ErrInvalidToken,
+		}),
+		timeout:   cfg.RemoteTimeout,
+		audiences: cfg.Audiences,
+		issuer:    cfg.Issuer,
+		clockSkew: cfg.ClockSkew,
+		log:       log,
+	}
+}
+
+func (v *remoteVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, err := v.verify(ctx, token)
+	observe(ModeRemote, err)
+	return claims, err
+}
+
+// ------- HELPERS -------
+
+func (v *remoteVerifier) verify(ctx context.Context, token string) (*Claims, error) {
+	var tc tokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &tc); err != nil {
+		return nil, apperrors.ErrInvalidToken.Wrap(err)
+	}
+	if !slices.ContainsFunc(v.audiences, func(aud string) bool { return slices.Contains(tc.Audience, aud) }) {
+		return nil, apperrors.ErrInvalidToken.Withf("invalid token audience")
+	}
+	if v.issuer != "" && tc.Issuer != v.issuer {
+		return nil, apperrors.ErrInvalidToken.Withf("invalid token issuer")
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	claims, err := v.results.GetOrLoad(ctx, hex.EncodeToString(sum[:]), func(ctx context.Context) (Claims, error) {
+		return v.validate(ctx, token, &tc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A cached answer can outlive the token.
+	if !claims.ExpiresAt.IsZero() && time.Now().After(claims.ExpiresAt.Add(v.clockSkew)) {
+		return nil, apperrors.ErrInvalidToken.Withf("token has expired")
+	}
+
+	return &claims, nil
+}
+
+func (v *remoteVerifier) validate(ctx context.Context, token string, tc *tokenClaims) (Claims, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	isValid, userID, username, role, _, err := v.client.ValidateToken(ctx, token)
+	if status.Code(err) == codes.Unauthenticated || (err == nil && !isValid) {
+		return Claims{}, apperrors.ErrInvalidToken
+	}
+	if err != nil {
+		logctx.From(ctx, v.log).WithError(err).Warn("Account service token validation failed")
+		if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded || status.Code(err) == codes.Unavailable {
+			return Claims{}, apperrors.ErrAuthUnavailable.Wrap(err)
+		}
+		return Claims{}, apperrors.ErrInternalServerError.Wrap(err)
+	}
+
+	claims := tc.toClaims()
+	claims.UserID = userID
+	claims.Username = username
+	claims.Role = role
+
+	return *claims, nil
+}
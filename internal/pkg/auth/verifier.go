@@ -0,0 +1,115 @@
+// Package auth verifies bearer tokens issued by the account service, either locally against its
+// published JWKS, remotely over gRPC, or locally with a remote fallback.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+const (
+	ModeRemote = "remote"
+	ModeLocal  = "local"
+	ModeHybrid = "hybrid"
+)
+
+// Claims is the caller identity extracted from a verified token.
+type Claims struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	TokenID   string    `json:"jti,omitempty"`
+	ExpiresAt time.Time `json:"exp,omitempty"`
+}
+
+// Verifier checks a raw bearer token. Rejected tokens are reported as ErrInvalidToken, and an
+// outage that prevents a decision as ErrAuthUnavailable.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// NewVerifier builds the verifier selected by cfg.Mode. keys is only used, and then required,
+// in local and hybrid modes.
+func NewVerifier(cfg *configs.AuthConfig, keys *KeySet, authClient *account.AuthClient, store *cache.Store, redisClient *customRedis.RedisClient, log *logrus.Logger) (Verifier, error) {
+	var verifier Verifier
+
+	switch cfg.Mode {
+	case ModeRemote:
+		verifier = newRemoteVerifier(cfg, authClient, store, log)
+	case ModeLocal, ModeHybrid:
+		if keys == nil {
+			return nil, fmt.Errorf("auth: mode %q requires a JWKS", cfg.Mode)
+		}
+
+		local := newLocalVerifier(cfg, keys, log)
+		if cfg.Mode == ModeHybrid {
+			local.fallback = newRemoteVerifier(cfg, authClient, store, log)
+		}
+		verifier = local
+	default:
+		return nil, fmt.Errorf("auth: unknown mode %q", cfg.Mode)
+	}
+
+	if cfg.RevocationEnabled {
+		verifier = &revocationVerifier{
+			next:        verifier,
+			redisClient: redisClient,
+			prefix:      cfg.RevocationKeyPrefix,
+			failClosed:  cfg.RevocationFailClosed,
+			log:         log,
+		}
+	}
+
+	return verifier, nil
+}
+
+// tokenClaims is the account service's token payload.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (tc *tokenClaims) toClaims() *Claims {
+	claims := &Claims{
+		UserID:   tc.UserID,
+		Username: tc.Username,
+		Role:     tc.Role,
+		TokenID:  tc.ID,
+	}
+	if claims.UserID == "" {
+		claims.UserID = tc.Subject
+	}
+	if tc.ExpiresAt != nil {
+		claims.ExpiresAt = tc.ExpiresAt.Time
+	}
+
+	return claims
+}
+
+// ------- HELPERS -------
+
+func observe(method string, err error) {
+	result := metrics.AuthValid
+	switch {
+	case err == nil:
+	case apperrors.From(err).Kind == apperrors.KindUnauthenticated:
+		result = metrics.AuthInvalid
+	default:
+		result = metrics.AuthUnavailable
+	}
+
+	metrics.AuthVerifications.WithLabelValues(method, result).Inc()
+}
@@ -0,0 +1,596 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+
+	authpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/auth"
+)
+
+const (
+	testAudience = "catalog"
+	testIssuer   = "account"
+	testSkew     = 30 * time.Second
+)
+
+var (
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+	if ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		panic(err)
+	}
+
+	m.Run()
+}
+
+func TestLocalVerifier(t *testing.T) {
+	srv := newJWKSServer(t, rsaJWK("rsa"), ecJWK("ec", &ecKey.PublicKey))
+	v := newTestVerifier(t, ModeLocal, srv.URL, nil)
+
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPKIX(t, &rsaKey.PublicKey)})
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "RS256", token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())},
+		{name: "ES256", token: sign(t, jwt.SigningMethodES256, ecKey, "ec", validClaims())},
+		{name: "RS512 is not an accepted algorithm", token: sign(t, jwt.SigningMethodRS512, rsaKey, "rsa", validClaims()), wantErr: apperrors.ErrInvalidToken},
+		{name: "PS256 is not an accepted algorithm", token: sign(t, jwt.SigningMethodPS256, rsaKey, "rsa", validClaims()), wantErr: apperrors.ErrInvalidToken},
+		{name: "alg none", token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "rsa", validClaims()), wantErr: apperrors.ErrInvalidToken},
+		{name: "HS256 keyed with the public key", token: sign(t, jwt.SigningMethodHS256, publicPEM, "rsa", validClaims()), wantErr: apperrors.ErrInvalidToken},
+		{name: "signed by another key", token: sign(t, jwt.SigningMethodES256, mustECKey(t), "ec", validClaims()), wantErr: apperrors.ErrInvalidToken},
+		{
+			name:  "expired within clock skew",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", withExpiry(validClaims(), time.Now().Add(-testSkew/2))),
+		},
+		{
+			name:    "expired past clock skew",
+			token:   sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", withExpiry(validClaims(), time.Now().Add(-2*testSkew))),
+			wantErr: apperrors.ErrInvalidToken,
+		},
+		{
+			name:    "no expiry",
+			token:   sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", withExpiry(validClaims(), time.Time{})),
+			wantErr: apperrors.ErrInvalidToken,
+		},
+		{
+			name: "wrong audience",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", func() tokenClaims {
+				tc := validClaims()
+				tc.Audience = jwt.ClaimStrings{"orders"}
+				return tc
+			}()),
+			wantErr: apperrors.ErrInvalidToken,
+		},
+		{
+			name: "wrong issuer",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", func() tokenClaims {
+				tc := validClaims()
+				tc.Issuer = "someone-else"
+				return tc
+			}()),
+			wantErr: apperrors.ErrInvalidToken,
+		},
+		{name: "garbage", token: "not.a.token", wantErr: apperrors.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Verify error = %v", err)
+			}
+			if claims.UserID != "user-1" || claims.Role != "buyer" || claims.TokenID != "jti-1" {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestUnknownKidRefreshIsThrottled(t *testing.T) {
+	srv := newJWKSServer(t, rsaJWK("rsa"))
+	keys := newTestKeySet(t, srv.URL)
+	v := newTestVerifier(t, ModeLocal, srv.URL, keys)
+	ctx := context.Background()
+
+	rotated := sign(t, jwt.SigningMethodES256, ecKey, "rotated", validClaims())
+	srv.setKeys(rsaJWK("rsa"), ecJWK("rotated", &ecKey.PublicKey))
+
+	// The set was loaded a moment ago, so the unknown kid does not refetch yet.
+	if _, err := v.Verify(ctx, rotated); !errors.Is(err, apperrors.ErrInvalidToken) {
+		t.Fatalf("Verify inside the throttle window = %v, want ErrInvalidToken", err)
+	}
+	if got := srv.hits.Load(); got != 1 {
+		t.Fatalf("JWKS fetched %d times, want 1", got)
+	}
+
+	keys.mu.Lock()
+	keys.lastAttempt = time.Now().Add(-minRefreshInterval)
+	keys.mu.Unlock()
+
+	if _, err := v.Verify(ctx, rotated); err != nil {
+		t.Fatalf("Verify after the throttle window = %v, want the rotated key picked up", err)
+	}
+	if got := srv.hits.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times, want 2", got)
+	}
+
+	forged := sign(t, jwt.SigningMethodES256, ecKey, "forged", validClaims())
+	for i := 0; i < 5; i++ {
+		if _, err := v.Verify(ctx, forged); !errors.Is(err, apperrors.ErrInvalidToken) {
+			t.Fatalf("Verify with an unknown kid = %v, want ErrInvalidToken", err)
+		}
+	}
+	if got := srv.hits.Load(); got != 2 {
+		t.Errorf("JWKS fetched %d times after repeated unknown kids, want 2", got)
+	}
+}
+
+func TestHybridFallsBackToRemote(t *testing.T) {
+	tests := []struct {
+		name        string
+		jwksStatus  int
+		token       func(t *testing.T) string
+		wantRemote  bool
+		wantErr     error
+		wantLocalID string
+	}{
+		{
+			name:       "key set unavailable",
+			jwksStatus: http.StatusInternalServerError,
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())
+			},
+			wantRemote: true,
+		},
+		{
+			name:       "key not found",
+			jwksStatus: http.StatusOK,
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodES256, ecKey, "unknown", validClaims())
+			},
+			wantRemote: true,
+		},
+		{
+			name:       "known key decides locally",
+			jwksStatus: http.StatusOK,
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())
+			},
+			wantLocalID: "user-1",
+		},
+		{
+			name:       "bad signature is not retried remotely",
+			jwksStatus: http.StatusOK,
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodES256, mustECKey(t), "ec", validClaims())
+			},
+			wantErr: apperrors.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newJWKSServer(t, rsaJWK("rsa"), ecJWK("ec", &ecKey.PublicKey))
+			srv.status.Store(int32(tt.jwksStatus))
+
+			remote := &fakeAuthService{resp: &authpb.ValidateTokenResponse{IsValid: true, UserId: "remote-user", Role: "buyer"}}
+			v := newTestVerifierWithRemote(t, ModeHybrid, srv.URL, remote)
+
+			claims, err := v.Verify(context.Background(), tt.token(t))
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Verify error = %v", err)
+			}
+
+			if got := remote.calls.Load() > 0; got != tt.wantRemote {
+				t.Errorf("remote called = %t, want %t", got, tt.wantRemote)
+			}
+			if tt.wantRemote && claims.UserID != "remote-user" {
+				t.Errorf("UserID = %q, want the account service's answer", claims.UserID)
+			}
+			if tt.wantLocalID != "" && claims.UserID != tt.wantLocalID {
+				t.Errorf("UserID = %q, want %q", claims.UserID, tt.wantLocalID)
+			}
+		})
+	}
+}
+
+func TestECKeyValidation(t *testing.T) {
+	valid := ecJWK("ec", &ecKey.PublicKey)
+
+	offCurve := valid
+	y := new(big.Int).Add(ecKey.PublicKey.Y, big.NewInt(1))
+	offCurve.Y = base64.RawURLEncoding.EncodeToString(y.FillBytes(make([]byte, 32)))
+
+	tooLarge := valid
+	tooLarge.X = base64.RawURLEncoding.EncodeToString(append([]byte{1}, make([]byte, 32)...))
+
+	otherCurve := valid
+	otherCurve.Crv = "P-384"
+
+	tests := []struct {
+		name    string
+		jwk     jsonWebKey
+		wantErr bool
+	}{
+		{name: "on curve", jwk: valid},
+		{name: "off curve", jwk: offCurve, wantErr: true},
+		{name: "coordinate too large", jwk: tooLarge, wantErr: true},
+		{name: "unsupported curve", jwk: otherCurve, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.jwk.publicKey()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("publicKey error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("key set skips it", func(t *testing.T) {
+		srv := newJWKSServer(t, offCurve)
+		keys := newTestKeySet(t, srv.URL)
+
+		if err := keys.Check(context.Background()); !errors.Is(err, ErrKeySetUnavailable) {
+			t.Errorf("Check = %v, want ErrKeySetUnavailable", err)
+		}
+	})
+}
+
+func TestRevocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenID    string
+		revoked    bool
+		redisDown  bool
+		failClosed bool
+		wantErr    error
+	}{
+		{name: "not revoked", tokenID: "jti-1"},
+		{name: "revoked, fail open", tokenID: "jti-1", revoked: true, wantErr: apperrors.ErrInvalidToken},
+		{name: "revoked, fail closed", tokenID: "jti-1", revoked: true, failClosed: true, wantErr: apperrors.ErrInvalidToken},
+		{name: "redis down, fail open", tokenID: "jti-1", redisDown: true},
+		{name: "redis down, fail closed", tokenID: "jti-1", redisDown: true, failClosed: true, wantErr: apperrors.ErrAuthUnavailable},
+		{name: "no jti is not checked", redisDown: true, failClosed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr, redisClient := newTestRedis(t)
+			if tt.revoked {
+				mr.Set("auth:revoked:"+tt.tokenID, "1")
+			}
+			if tt.redisDown {
+				mr.Close()
+			}
+
+			v := &revocationVerifier{
+				next:        staticVerifier{claims: &Claims{UserID: "user-1", TokenID: tt.tokenID}},
+				redisClient: redisClient,
+				prefix:      "auth:revoked:",
+				failClosed:  tt.failClosed,
+				log:         discardLogger(),
+			}
+
+			claims, err := v.Verify(context.Background(), "token")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || claims.UserID != "user-1" {
+				t.Fatalf("Verify = %+v, %v; want the claims passed through", claims, err)
+			}
+		})
+	}
+}
+
+func TestRemoteCachedResultExpires(t *testing.T) {
+	remote := &fakeAuthService{resp: &authpb.ValidateTokenResponse{IsValid: true, UserId: "user-1", Role: "buyer"}}
+	v := newRemoteVerifier(testConfig(ModeRemote, ""), account.NewAuthClientFromService(remote, nil), newTestStore(t), discardLogger())
+	ctx := context.Background()
+
+	fresh := sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())
+	for i := 0; i < 2; i++ {
+		if _, err := v.Verify(ctx, fresh); err != nil {
+			t.Fatalf("Verify = %v", err)
+		}
+	}
+	if got := remote.calls.Load(); got != 1 {
+		t.Fatalf("account service called %d times, want the second answer cached", got)
+	}
+
+	// The account service accepted this token while it was still valid; the answer is still cached.
+	expiresAt := time.Now().Add(-2 * testSkew)
+	stale := sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", withExpiry(validClaims(), expiresAt))
+	sum := sha256.Sum256([]byte(stale))
+	if _, err := v.results.GetOrLoad(ctx, hex.EncodeToString(sum[:]), func(ctx context.Context) (Claims, error) {
+		return Claims{UserID: "user-1", Role: "buyer", ExpiresAt: expiresAt}, nil
+	}); err != nil {
+		t.Fatalf("seeding the cache: %v", err)
+	}
+
+	if _, err := v.Verify(ctx, stale); !errors.Is(err, apperrors.ErrInvalidToken) {
+		t.Errorf("Verify with a cached answer past exp = %v, want ErrInvalidToken", err)
+	}
+	if got := remote.calls.Load(); got != 1 {
+		t.Errorf("account service called %d times, want the stale answer rejected from cache", got)
+	}
+}
+
+// ------- HELPERS -------
+
+type jwksServer struct {
+	*httptest.Server
+	hits   atomic.Int32
+	status atomic.Int32
+
+	mu  sync.Mutex
+	doc []byte
+}
+
+func newJWKSServer(t *testing.T, keys ...jsonWebKey) *jwksServer {
+	t.Helper()
+
+	srv := &jwksServer{}
+	srv.status.Store(http.StatusOK)
+	srv.setKeys(keys...)
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.hits.Add(1)
+		if status := int(srv.status.Load()); status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		w.Write(srv.doc)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func (s *jwksServer) setKeys(keys ...jsonWebKey) {
+	doc, _ := json.Marshal(map[string][]jsonWebKey{"keys": keys})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.doc = doc
+}
+
+func rsaJWK(kid string) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(rsaKey.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.PublicKey.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func validClaims() tokenClaims {
+	return tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			Subject:   "user-1",
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		UserID: "user-1",
+		Role:   "buyer",
+	}
+}
+
+func withExpiry(tc tokenClaims, expiresAt time.Time) tokenClaims {
+	tc.ExpiresAt = nil
+	if !expiresAt.IsZero() {
+		tc.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	}
+	return tc
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, tc tokenClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, &tc)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing %s token: %v", method.Alg(), err)
+	}
+	return signed
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustMarshalPKIX(t *testing.T, key interface{}) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func testConfig(mode, jwksURL string) *configs.AuthConfig {
+	return &configs.AuthConfig{
+		Mode:                mode,
+		Audiences:           []string{testAudience},
+		Issuer:              testIssuer,
+		ClockSkew:           testSkew,
+		JWKSURL:             jwksURL,
+		JWKSRefreshInterval: time.Hour,
+		RemoteTimeout:       time.Second,
+		RemoteCacheTTL:      time.Minute,
+		RemoteNegativeTTL:   10 * time.Second,
+	}
+}
+
+func newTestKeySet(t *testing.T, jwksURL string) *KeySet {
+	t.Helper()
+
+	keys, err := NewKeySet(testConfig(ModeLocal, jwksURL), discardLogger())
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	return keys
+}
+
+func newTestVerifier(t *testing.T, mode, jwksURL string, keys *KeySet) Verifier {
+	t.Helper()
+
+	if keys == nil {
+		keys = newTestKeySet(t, jwksURL)
+	}
+
+	v, err := NewVerifier(testConfig(mode, jwksURL), keys, nil, nil, nil, discardLogger())
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	return v
+}
+
+func newTestVerifierWithRemote(t *testing.T, mode, jwksURL string, remote authpb.AuthServiceClient) Verifier {
+	t.Helper()
+
+	_, redisClient := newTestRedis(t)
+	store := cache.NewStore(redisClient, &configs.CacheConfig{DefaultTTL: time.Minute, NegativeTTL: 10 * time.Second}, discardLogger())
+
+	v, err := NewVerifier(testConfig(mode, jwksURL), newTestKeySet(t, jwksURL), account.NewAuthClientFromService(remote, nil), store, redisClient, discardLogger())
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	return v
+}
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *customRedis.RedisClient) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	port, _ := strconv.Atoi(mr.Port())
+
+	client, err := customRedis.NewRedisClient(&configs.RedisConfig{
+		Host:                    mr.Host(),
+		Port:                    port,
+		DialTimeout:             time.Second,
+		OperationTimeout:        time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, discardLogger())
+	if err != nil {
+		t.Fatalf("NewRedisClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return mr, client
+}
+
+func newTestStore(t *testing.T) *cache.Store {
+	t.Helper()
+
+	_, redisClient := newTestRedis(t)
+	return cache.NewStore(redisClient, &configs.CacheConfig{DefaultTTL: time.Minute, NegativeTTL: 10 * time.Second}, discardLogger())
+}
+
+func discardLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+// fakeAuthService answers ValidateToken with a fixed response.
+type fakeAuthService struct {
+	authpb.AuthServiceClient
+	resp  *authpb.ValidateTokenResponse
+	calls atomic.Int32
+}
+
+func (f *fakeAuthService) ValidateToken(ctx context.Context, in *authpb.ValidateTokenRequest, opts ...grpc.CallOption) (*authpb.ValidateTokenResponse, error) {
+	f.calls.Add(1)
+	return f.resp, nil
+}
+
+type staticVerifier struct {
+	claims *Claims
+}
+
+func (v staticVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims := *v.claims
+	return &claims, nil
+}
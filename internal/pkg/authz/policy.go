@@ -0,0 +1,102 @@
+// Package authz decides what an authenticated caller may do. Roles are mapped to named
+// permissions in configuration, so handlers and services never compare role strings. The policy
+// has no transport dependency and is shared by the HTTP middleware and the services behind both
+// the HTTP and gRPC servers.
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Permission string
+
+const (
+	// ProductWriteOwn allows creating products and managing the caller's own listings, including
+	// replying to their reviews and answering their questions.
+	ProductWriteOwn Permission = "product:write:own"
+	// ProductWriteAny allows managing any seller's listings.
+	ProductWriteAny  Permission = "product:write:any"
+	CacheAdmin       Permission = "cache:admin"
+	ModerationReview Permission = "moderation:review"
+	AnalyticsReadOwn Permission = "analytics:read:own"
+	// ConfigRead allows viewing the service's effective configuration, with secrets redacted.
+	ConfigRead Permission = "config:read"
+)
+
+var known = map[Permission]struct{}{
+	ProductWriteOwn:  {},
+	ProductWriteAny:  {},
+	CacheAdmin:       {},
+	ModerationReview: {},
+	AnalyticsReadOwn: {},
+	ConfigRead:       {},
+}
+
+// Scope pairs the permission to act on the caller's own resources with the one to act on anyone's.
+type Scope struct {
+	Own Permission
+	Any Permission
+}
+
+var ProductWrite = Scope{Own: ProductWriteOwn, Any: ProductWriteAny}
+
+type Policy struct {
+	grants map[string]map[Permission]struct{}
+}
+
+// NewPolicy parses role mappings of the form "role=perm,perm". Unknown permissions are rejected
+// so a typo cannot silently remove access.
+func NewPolicy(mappings []string) (*Policy, error) {
+	p := &Policy{grants: make(map[string]map[Permission]struct{}, len(mappings))}
+
+	for _, mapping := range mappings {
+		role, perms, ok := strings.Cut(strings.TrimSpace(mapping), "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("authz: invalid role mapping %q, want role=perm,perm", mapping)
+		}
+
+		granted := p.grants[role]
+		if granted == nil {
+			granted = make(map[Permission]struct{})
+			p.grants[role] = granted
+		}
+
+		for _, perm := range strings.Split(perms, ",") {
+			perm := Permission(strings.TrimSpace(perm))
+			if perm == "" {
+				continue
+			}
+			if _, ok := known[perm]; !ok {
+				return nil, fmt.Errorf("authz: role %q has unknown permission %q", role, perm)
+			}
+			granted[perm] = struct{}{}
+		}
+	}
+
+	return p, nil
+}
+
+// Allows reports whether role holds at least one of perms.
+func (p *Policy) Allows(role string, perms ...Permission) bool {
+	granted := p.grants[role]
+	for _, perm := range perms {
+		if _, ok := granted[perm]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsOn reports whether the caller may act on a resource owned by ownerID within scope.
+func (p *Policy) AllowsOn(role string, callerID uuid.UUID, scope Scope, ownerID uuid.UUID) bool {
+	if p.Allows(role, scope.Any) {
+		return true
+	}
+
+	return p.Allows(role, scope.Own) && callerID == ownerID
+}
@@ -0,0 +1,113 @@
+package authz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []string
+		wantErr  string
+	}{
+		{name: "valid", mappings: []string{"admin=product:write:any,cache:admin", "seller=product:write:own"}},
+		{name: "whitespace is trimmed", mappings: []string{" seller = product:write:own , analytics:read:own "}},
+		{name: "role without permissions", mappings: []string{"buyer="}},
+		{name: "unknown permission", mappings: []string{"seller=product:write:own,product:delete"}, wantErr: `unknown permission "product:delete"`},
+		{name: "typo in a permission", mappings: []string{"admin=cache:admn"}, wantErr: "unknown permission"},
+		{name: "missing separator", mappings: []string{"admin"}, wantErr: "invalid role mapping"},
+		{name: "missing role", mappings: []string{"=cache:admin"}, wantErr: "invalid role mapping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolicy(tt.mappings)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewPolicy error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewPolicy error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowsOn(t *testing.T) {
+	policy, err := NewPolicy([]string{
+		"admin=product:write:any",
+		"seller=product:write:own",
+		"moderator=product:write:own,product:write:any",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caller, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name  string
+		role  string
+		owner uuid.UUID
+		want  bool
+	}{
+		{name: "own applies to the caller's resource", role: "seller", owner: caller, want: true},
+		{name: "own does not apply to another owner", role: "seller", owner: other, want: false},
+		{name: "any applies to another owner", role: "admin", owner: other, want: true},
+		{name: "any applies to the caller's resource", role: "admin", owner: caller, want: true},
+		{name: "any overrides ownership", role: "moderator", owner: other, want: true},
+		{name: "no grant on the caller's resource", role: "buyer", owner: caller, want: false},
+		{name: "unknown role", role: "", owner: caller, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.AllowsOn(tt.role, caller, ProductWrite, tt.owner); got != tt.want {
+				t.Errorf("AllowsOn(%q) = %t, want %t", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRolePermissions(t *testing.T) {
+	field, ok := reflect.TypeOf(configs.AuthConfig{}).FieldByName("RolePermissions")
+	if !ok {
+		t.Fatal("AuthConfig has no RolePermissions field")
+	}
+
+	policy, err := NewPolicy(strings.Split(field.Tag.Get("envDefault"), field.Tag.Get("envSeparator")))
+	if err != nil {
+		t.Fatalf("default AUTH_ROLE_PERMISSIONS does not parse: %v", err)
+	}
+
+	tests := []struct {
+		role string
+		perm Permission
+		want bool
+	}{
+		{role: "admin", perm: ProductWriteAny, want: true},
+		{role: "admin", perm: CacheAdmin, want: true},
+		{role: "admin", perm: ModerationReview, want: true},
+		{role: "admin", perm: ConfigRead, want: true},
+		{role: "seller", perm: ProductWriteOwn, want: true},
+		{role: "seller", perm: AnalyticsReadOwn, want: true},
+		{role: "seller", perm: ProductWriteAny, want: false},
+		{role: "seller", perm: CacheAdmin, want: false},
+		{role: "buyer", perm: ProductWriteOwn, want: false},
+		{role: "buyer", perm: CacheAdmin, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Allows(tt.role, tt.perm); got != tt.want {
+			t.Errorf("Allows(%q, %q) = %t, want %t", tt.role, tt.perm, got, tt.want)
+		}
+	}
+}
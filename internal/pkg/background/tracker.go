@@ -0,0 +1,71 @@
+package background
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Tracker runs fire-and-forget work that shutdown must wait for, such as cache invalidation
+// after a write. Tasks started after shutdown has begun run inline so they are not lost.
+type Tracker struct {
+	// mu orders Go's stopping check and wg.Add against Wait, so no task is added to a
+	// WaitGroup that is already being waited on.
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	stopping bool
+	log      *logrus.Logger
+}
+
+func NewTracker(log *logrus.Logger) *Tracker {
+	return &Tracker{log: log}
+}
+
+func (t *Tracker) Go(name string, fn func()) {
+	t.mu.Lock()
+	if t.stopping {
+		t.mu.Unlock()
+		t.run(name, fn)
+		return
+	}
+	t.wg.Add(1)
+	t.mu.Unlock()
+
+	go func() {
+		defer t.wg.Done()
+		t.run(name, fn)
+	}()
+}
+
+// Wait blocks until every tracked task has returned or ctx expires.
+func (t *Tracker) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	t.stopping = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ------- HELPERS -------
+
+func (t *Tracker) run(name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.log.WithField("task", name).Errorf("Background task panicked: %v", r)
+		}
+	}()
+
+	fn()
+}
@@ -0,0 +1,83 @@
+package background
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestTracker() *Tracker {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return NewTracker(log)
+}
+
+func TestWaitWaitsForTasks(t *testing.T) {
+	tr := newTestTracker()
+
+	var done atomic.Bool
+	tr.Go("slow", func() {
+		time.Sleep(20 * time.Millisecond)
+		done.Store(true)
+	})
+
+	if err := tr.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if !done.Load() {
+		t.Error("Wait returned before the task finished")
+	}
+}
+
+func TestTaskAfterWaitRunsInline(t *testing.T) {
+	tr := newTestTracker()
+	if err := tr.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+
+	var ran bool
+	tr.Go("late", func() { ran = true })
+	if !ran {
+		t.Error("task started after Wait did not run inline")
+	}
+
+	// Panics are recovered on the inline path too.
+	tr.Go("late_panic", func() { panic("boom") })
+}
+
+// Run with -race: Go must never call wg.Add while Wait is already waiting.
+func TestGoConcurrentWithWait(t *testing.T) {
+	tr := newTestTracker()
+
+	start := make(chan struct{})
+	for i := 0; i < 100; i++ {
+		go func() {
+			<-start
+			tr.Go("racing", func() {})
+		}()
+	}
+
+	close(start)
+	if err := tr.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+}
+
+func TestWaitHonoursContext(t *testing.T) {
+	tr := newTestTracker()
+
+	release := make(chan struct{})
+	defer close(release)
+	tr.Go("stuck", func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := tr.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
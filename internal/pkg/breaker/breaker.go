@@ -0,0 +1,204 @@
+// Package breaker implements a consecutive-failure circuit breaker shared by the clients of
+// external dependencies.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrOpen is returned instead of calling the dependency while the breaker considers it unavailable.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int32
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker trips after consecutive connection failures so callers stop queueing behind
+// timeouts. After openTimeout a single probe is let through; its outcome closes or re-opens it.
+type CircuitBreaker struct {
+	name        string
+	mu          sync.Mutex
+	state       State
+	failures    int
+	threshold   int
+	openTimeout time.Duration
+	openedAt    time.Time
+	probing     bool
+	listeners   []func(from, to State)
+	log         *logrus.Logger
+}
+
+// New returns a closed breaker. name identifies the dependency in logs.
+func New(name string, threshold int, openTimeout time.Duration, log *logrus.Logger) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		log:         log,
+	}
+}
+
+// Allow reports whether a call may go to the dependency right now. In the half-open state only the
+// caller that received nil is the probe.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		notify := b.transition(StateHalfOpen)
+		b.probing = true
+		b.mu.Unlock()
+		notify()
+		return nil
+
+	case StateHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		b.probing = true
+	}
+
+	b.mu.Unlock()
+	return nil
+}
+
+// Healthy reports whether callers should use the dependency. It does not consume the half-open probe,
+// so an open breaker past its timeout reads as healthy and the next real call probes it.
+func (b *CircuitBreaker) Healthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		return time.Since(b.openedAt) >= b.openTimeout
+	case StateHalfOpen:
+		return !b.probing
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	b.failures = 0
+	b.probing = false
+	notify := b.transition(StateClosed)
+	b.mu.Unlock()
+
+	notify()
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	b.probing = false
+
+	notify := func() {}
+	switch b.state {
+	case StateHalfOpen:
+		notify = b.open()
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.threshold {
+			notify = b.open()
+		}
+	}
+	b.mu.Unlock()
+
+	notify()
+}
+
+// Release records no verdict for a call that was allowed, e.g. one its caller cancelled. A held
+// half-open probe is handed back so the next call probes instead.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Trip opens the breaker immediately, e.g. when the startup ping fails.
+func (b *CircuitBreaker) Trip() {
+	b.mu.Lock()
+	notify := b.open()
+	b.mu.Unlock()
+
+	notify()
+}
+
+// OnStateChange registers fn to run after every transition, outside the breaker lock.
+func (b *CircuitBreaker) OnStateChange(fn func(from, to State)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listeners = append(b.listeners, fn)
+}
+
+func (b *CircuitBreaker) open() func() {
+	b.openedAt = time.Now()
+	return b.transition(StateOpen)
+}
+
+// transition must be called with mu held; the returned func runs the listeners and must be
+// called after unlocking.
+func (b *CircuitBreaker) transition(to State) func() {
+	from := b.state
+	if from == to {
+		return func() {}
+	}
+
+	b.state = to
+	if to == StateClosed {
+		b.failures = 0
+	}
+
+	entry := b.log.WithFields(logrus.Fields{"breaker": b.name, "from": from.String(), "to": to.String()})
+	if to == StateOpen {
+		entry.Warn("Circuit breaker opened")
+	} else {
+		entry.Info("Circuit breaker state changed")
+	}
+
+	listeners := append([]func(from, to State){}, b.listeners...)
+	return func() {
+		for _, fn := range listeners {
+			fn(from, to)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package breaker
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const testOpenTimeout = 20 * time.Millisecond
+
+func newTestBreaker(threshold int) *CircuitBreaker {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return New("test", threshold, testOpenTimeout, log)
+}
+
+// openAndWait trips b through failures and waits out the open timeout, leaving the next Allow as the probe.
+func openAndWait(t *testing.T, b *CircuitBreaker) {
+	t.Helper()
+
+	for b.State() != StateOpen {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("Allow while closed = %v", err)
+		}
+		b.Failure()
+	}
+	time.Sleep(testOpenTimeout + 5*time.Millisecond)
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b := newTestBreaker(3)
+
+	for i := 0; i < 2; i++ {
+		b.Failure()
+	}
+	b.Success()
+	for i := 0; i < 2; i++ {
+		b.Failure()
+	}
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("state after non-consecutive failures = %s, want closed", got)
+	}
+
+	b.Failure()
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("state after %d consecutive failures = %s, want open", 3, got)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("Allow while open = %v, want ErrOpen", err)
+	}
+	if b.Healthy() {
+		t.Error("Healthy while open = true")
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name   string
+		settle func(b *CircuitBreaker)
+		want   State
+	}{
+		{name: "probe succeeds", settle: (*CircuitBreaker).Success, want: StateClosed},
+		{name: "probe fails", settle: (*CircuitBreaker).Failure, want: StateOpen},
+		{name: "probe released", settle: (*CircuitBreaker).Release, want: StateHalfOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBreaker(1)
+			openAndWait(t, b)
+
+			if !b.Healthy() {
+				t.Error("Healthy after the open timeout = false, want true")
+			}
+			if err := b.Allow(); err != nil {
+				t.Fatalf("Allow after the open timeout = %v, want the probe", err)
+			}
+			if got := b.State(); got != StateHalfOpen {
+				t.Fatalf("state during the probe = %s, want half_open", got)
+			}
+			if err := b.Allow(); !errors.Is(err, ErrOpen) {
+				t.Errorf("second Allow during the probe = %v, want ErrOpen", err)
+			}
+
+			tt.settle(b)
+
+			if got := b.State(); got != tt.want {
+				t.Errorf("state after the probe = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerReleasedProbeIsReissued(t *testing.T) {
+	b := newTestBreaker(1)
+	openAndWait(t, b)
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow = %v, want the probe", err)
+	}
+	b.Release()
+
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow after Release = %v, want a new probe", err)
+	}
+	b.Success()
+	if got := b.State(); got != StateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestBreakerAdmitsSingleProbe(t *testing.T) {
+	b := newTestBreaker(1)
+	openAndWait(t, b)
+
+	const callers = 50
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.Allow() == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Errorf("%d concurrent callers were allowed through the half-open breaker, want 1", allowed)
+	}
+}
+
+func TestBreakerNotifiesTransitions(t *testing.T) {
+	b := newTestBreaker(1)
+
+	var got []State
+	b.OnStateChange(func(from, to State) {
+		got = append(got, to)
+	})
+
+	openAndWait(t, b)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow = %v, want the probe", err)
+	}
+	b.Success()
+
+	want := []State{StateOpen, StateHalfOpen, StateClosed}
+	if len(got) != len(want) {
+		t.Fatalf("transitions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+)
+
+type Options struct {
+	// Namespace prefixes every key and is the unit of bulk invalidation.
+	Namespace string
+	// TTL and NegativeTTL fall back to the store defaults when zero.
+	TTL         time.Duration
+	NegativeTTL time.Duration
+	// NotFound marks loader errors that should be remembered as a negative entry.
+	// Leave nil to disable negative caching.
+	NotFound error
+	// L1Size bounds the in-process layer; zero uses the store default and a negative value disables it.
+	L1Size int
+}
+
+// entry is the on-the-wire envelope, so a cached "does not exist" can be told apart from a cache miss.
+type entry[T any] struct {
+	Value   T    `json:"v"`
+	Missing bool `json:"m,omitempty"`
+}
+
+// Cache is a typed cache-aside helper: an in-process LRU in front of Redis in front of the loader.
+// Concurrent misses for the same key share one loader call. Values handed out from the L1 or a shared
+// load are shared between callers, so treat them as read-only.
+type Cache[T any] struct {
+	store *Store
+	opts  Options
+	l1    *lru[entry[T]]
+	group singleflight.Group
+}
+
+func New[T any](store *Store, opts Options) *Cache[T] {
+	if opts.TTL <= 0 {
+		opts.TTL = store.defaultTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = store.negativeTTL
+	}
+
+	if opts.L1Size == 0 {
+		opts.L1Size = store.l1Size
+	}
+
+	c := &Cache[T]{store: store, opts: opts}
+	if opts.L1Size > 0 && store.l1TTL > 0 {
+		c.l1 = newLRU[entry[T]](opts.L1Size, store.l1TTL)
+	}
+
+	store.register(opts.Namespace, c)
+	return c
+}
+
+// GetOrLoad returns the cached value for key, calling load on a miss. Redis failures degrade to
+// calling load directly rather than failing the read.
+func (c *Cache[T]) GetOrLoad(ctx context.Context, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+
+	if !c.store.available() {
+		c.observe(metrics.CacheBypass, 1)
+		return load(ctx)
+	}
+
+	if cached, ok := c.getLocal(key); ok {
+		c.observe(metrics.CacheL1Hit, 1)
+		if cached.Missing {
+			return zero, c.opts.NotFound
+		}
+		return cached.Value, nil
+	}
+
+	redisKey, err := c.redisKey(ctx, key)
+	if err != nil {
+		c.store.log.WithField("namespace", c.opts.Namespace).WithError(err).Warn("Cache unavailable, loading from source")
+		c.observe(metrics.CacheBypass, 1)
+		return load(ctx)
+	}
+
+	if cached, ok := c.get(ctx, redisKey); ok {
+		c.observe(metrics.CacheHit, 1)
+		c.setLocal(key, cached)
+		if cached.Missing {
+			return zero, c.opts.NotFound
+		}
+		return cached.Value, nil
+	}
+
+	c.observe(metrics.CacheMiss, 1)
+	value, err, _ := c.group.Do(redisKey, func() (interface{}, error) {
+		// Detach from the first caller's cancellation; every waiter depends on this load.
+		loadCtx := context.WithoutCancel(ctx)
+
+		loaded, err := load(loadCtx)
+		if err != nil {
+			if c.isNotFound(err) {
+				c.setLocal(key, entry[T]{Missing: true})
+				c.set(loadCtx, redisKey, entry[T]{Missing: true}, c.opts.NegativeTTL)
+			}
+			return nil, err
+		}
+
+		c.setLocal(key, entry[T]{Value: loaded})
+		c.set(loadCtx, redisKey, entry[T]{Value: loaded}, c.opts.TTL)
+		return loaded, nil
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	return value.(T), nil
+}
+
+// GetOrLoadMany resolves a batch of keys with one MGET. load receives only the misses; any missed key
+// it does not return is cached as a negative entry when NotFound is configured.
+func (c *Cache[T]) GetOrLoadMany(ctx context.Context, keys []string, load func(ctx context.Context, missing []string) (map[string]T, error)) (map[string]T, error) {
+	if !c.store.available() {
+		c.observe(metrics.CacheBypass, len(keys))
+		return load(ctx, keys)
+	}
+
+	results := make(map[string]T, len(keys))
+
+	remote := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cached, ok := c.getLocal(key)
+		if !ok {
+			remote = append(remote, key)
+			continue
+		}
+		if !cached.Missing {
+			results[key] = cached.Value
+		}
+	}
+
+	c.observe(metrics.CacheL1Hit, len(keys)-len(remote))
+
+	keys = remote
+	if len(keys) == 0 {
+		return results, nil
+	}
+
+	version, err := c.store.namespaceVersion(ctx, c.opts.Namespace)
+	if err != nil {
+		c.store.log.WithField("namespace", c.opts.Namespace).WithError(err).Warn("Cache unavailable, loading from source")
+		c.observe(metrics.CacheBypass, len(keys))
+
+		loaded, err := load(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range loaded {
+			results[key] = value
+		}
+		return results, nil
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = c.versionedKey(version, key)
+	}
+
+	missing := make([]string, 0, len(keys))
+	cachedValues, err := c.store.client.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		c.store.log.WithField("namespace", c.opts.Namespace).WithError(err).Warn("Cache MGET failed, loading from source")
+		missing = append(missing, keys...)
+	} else {
+		for i, raw := range cachedValues {
+			cached, ok := decode[T](raw)
+			if !ok {
+				missing = append(missing, keys[i])
+				continue
+			}
+			c.setLocal(keys[i], cached)
+			if !cached.Missing {
+				results[keys[i]] = cached.Value
+			}
+		}
+	}
+
+	c.observe(metrics.CacheHit, len(keys)-len(missing))
+	c.observe(metrics.CacheMiss, len(missing))
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	loaded, err := load(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := c.store.client.Pipeline()
+	for _, key := range missing {
+		redisKey := c.versionedKey(version, key)
+
+		value, ok := loaded[key]
+		if ok {
+			results[key] = value
+			c.setLocal(key, entry[T]{Value: value})
+			c.queueSet(ctx, pipe, redisKey, entry[T]{Value: value}, c.opts.TTL)
+			continue
+		}
+
+		if c.opts.NotFound != nil {
+			c.setLocal(key, entry[T]{Missing: true})
+			c.queueSet(ctx, pipe, redisKey, entry[T]{Missing: true}, c.opts.NegativeTTL)
+		}
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.store.log.WithField("namespace", c.opts.Namespace).WithError(err).Warn("Failed to write batch to cache")
+	}
+
+	return results, nil
+}
+
+// Delete drops individual keys in the current namespace version, here and on every replica.
+func (c *Cache[T]) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	c.evictLocal(keys)
+
+	version, err := c.store.namespaceVersion(ctx, c.opts.Namespace)
+	if err != nil {
+		return err
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = c.versionedKey(version, key)
+	}
+
+	if err := c.store.client.Del(ctx, redisKeys...).Err(); err != nil {
+		return fmt.Errorf("cache: failed to delete keys in %s: %w", c.opts.Namespace, err)
+	}
+
+	c.store.broadcast(ctx, invalidation{Namespace: c.opts.Namespace, Keys: keys})
+	return nil
+}
+
+// Invalidate drops every key in the namespace, here and on every replica.
+func (c *Cache[T]) Invalidate(ctx context.Context) error {
+	return c.store.InvalidateNamespace(ctx, c.opts.Namespace)
+}
+
+func (c *Cache[T]) getLocal(key string) (entry[T], bool) {
+	if c.l1 == nil {
+		return entry[T]{}, false
+	}
+
+	return c.l1.get(key)
+}
+
+func (c *Cache[T]) setLocal(key string, value entry[T]) {
+	if c.l1 != nil {
+		c.l1.set(key, value)
+	}
+}
+
+func (c *Cache[T]) evictLocal(keys []string) {
+	if c.l1 == nil {
+		return
+	}
+
+	if len(keys) == 0 {
+		c.l1.purge()
+		return
+	}
+
+	c.l1.delete(keys...)
+}
+
+func (c *Cache[T]) observe(result string, n int) {
+	if n > 0 {
+		metrics.CacheLookups.WithLabelValues(c.opts.Namespace, result).Add(float64(n))
+	}
+}
+
+func (c *Cache[T]) redisKey(ctx context.Context, key string) (string, error) {
+	version, err := c.store.namespaceVersion(ctx, c.opts.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return c.versionedKey(version, key), nil
+}
+
+func (c *Cache[T]) versionedKey(version int64, key string) string {
+	return fmt.Sprintf("%s:v%d:%s", c.opts.Namespace, version, key)
+}
+
+func (c *Cache[T]) isNotFound(err error) bool {
+	return c.opts.NotFound != nil && errors.Is(err, c.opts.NotFound)
+}
+
+func (c *Cache[T]) get(ctx context.Context, redisKey string) (entry[T], bool) {
+	raw, err := c.store.client.Get(ctx, redisKey).Result()
+	if err != nil {
+		if err != redis.Nil {
+			c.store.log.WithField("key", redisKey).WithError(err).Warn("Cache read failed")
+		}
+		return entry[T]{}, false
+	}
+
+	return decode[T](raw)
+}
+
+func (c *Cache[T]) set(ctx context.Context, redisKey string, value entry[T], ttl time.Duration) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		c.store.log.WithField("key", redisKey).WithError(err).Warn("Failed to encode cache entry")
+		return
+	}
+
+	if err := c.store.client.Set(ctx, redisKey, payload, c.store.withJitter(ttl)).Err(); err != nil {
+		c.store.log.WithField("key", redisKey).WithError(err).Warn("Failed to set cache")
+	}
+}
+
+func (c *Cache[T]) queueSet(ctx context.Context, pipe redis.Pipeliner, redisKey string, value entry[T], ttl time.Duration) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		c.store.log.WithField("key", redisKey).WithError(err).Warn("Failed to encode cache entry")
+		return
+	}
+
+	pipe.Set(ctx, redisKey, payload, c.store.withJitter(ttl))
+}
+
+func decode[T any](raw interface{}) (entry[T], bool) {
+	var cached entry[T]
+
+	s, ok := raw.(string)
+	if !ok {
+		return cached, false
+	}
+
+	if err := json.Unmarshal([]byte(s), &cached); err != nil {
+		return cached, false
+	}
+
+	return cached, true
+}
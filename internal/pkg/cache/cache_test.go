@@ -0,0 +1,358 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+var errNotFound = errors.New("not found")
+
+func newTestStore(t *testing.T, cfg configs.CacheConfig) (*Store, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	port, _ := strconv.Atoi(mr.Port())
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	client, err := customRedis.NewRedisClient(&configs.RedisConfig{
+		Host:                    mr.Host(),
+		Port:                    port,
+		DialTimeout:             time.Second,
+		OperationTimeout:        time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, log)
+	if err != nil {
+		t.Fatalf("NewRedisClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	if cfg.DefaultTTL == 0 {
+		cfg.DefaultTTL = time.Minute
+	}
+	if cfg.NegativeTTL == 0 {
+		cfg.NegativeTTL = 10 * time.Second
+	}
+	if cfg.InvalidationChannel == "" {
+		cfg.InvalidationChannel = "test:invalidate"
+	}
+
+	return NewStore(client, &cfg, log), mr
+}
+
+// countingLoader returns value for every key and counts how often it ran.
+func countingLoader(calls *atomic.Int32, value string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return value, nil
+	}
+}
+
+func TestNamespaceBumpHidesOldEntries(t *testing.T) {
+	store, mr := newTestStore(t, configs.CacheConfig{})
+	c := New[string](store, Options{Namespace: "products", L1Size: -1})
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	for i := 0; i < 2; i++ {
+		got, err := c.GetOrLoad(ctx, "p1", countingLoader(&calls, "old"))
+		if err != nil || got != "old" {
+			t.Fatalf("GetOrLoad = %q, %v; want %q", got, err, "old")
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("loader ran %d times before invalidation, want 1", calls.Load())
+	}
+
+	if err := c.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+
+	got, err := c.GetOrLoad(ctx, "p1", countingLoader(&calls, "new"))
+	if err != nil || got != "new" {
+		t.Fatalf("GetOrLoad after invalidation = %q, %v; want %q", got, err, "new")
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("loader ran %d times, want 2", calls.Load())
+	}
+
+	// The old entry is orphaned rather than deleted, and ages out through its TTL.
+	if !mr.Exists("products:v0:p1") {
+		t.Error("entry under the previous version was deleted, want it left to expire")
+	}
+	if !mr.Exists("products:v1:p1") {
+		t.Error("no entry under the bumped version")
+	}
+}
+
+func TestNegativeEntryExpires(t *testing.T) {
+	store, mr := newTestStore(t, configs.CacheConfig{NegativeTTL: 5 * time.Second})
+	c := New[string](store, Options{Namespace: "products", NotFound: errNotFound, L1Size: -1})
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	missing := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "", errNotFound
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetOrLoad(ctx, "gone", missing); !errors.Is(err, errNotFound) {
+			t.Fatalf("GetOrLoad error = %v, want %v", err, errNotFound)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("loader ran %d times, want the second read served by the negative entry", calls.Load())
+	}
+
+	if ttl := mr.TTL("products:v0:gone"); ttl != 5*time.Second {
+		t.Errorf("negative entry TTL = %s, want 5s", ttl)
+	}
+
+	mr.FastForward(6 * time.Second)
+
+	got, err := c.GetOrLoad(ctx, "gone", countingLoader(&calls, "back"))
+	if err != nil || got != "back" {
+		t.Fatalf("GetOrLoad after expiry = %q, %v; want %q", got, err, "back")
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("loader ran %d times, want 2", calls.Load())
+	}
+}
+
+func TestConcurrentMissesShareOneLoad(t *testing.T) {
+	store, mr := newTestStore(t, configs.CacheConfig{})
+	c := New[string](store, Options{Namespace: "products", L1Size: -1})
+	ctx := context.Background()
+
+	const callers = 20
+
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return "value", nil
+	}
+
+	before := mr.CommandCount()
+
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.GetOrLoad(ctx, "hot", load)
+		}(i)
+	}
+
+	<-started
+	// Every caller reads the namespace version and the key before joining the shared load.
+	deadline := time.Now().Add(5 * time.Second)
+	for mr.CommandCount()-before < 2*callers {
+		if time.Now().After(deadline) {
+			t.Fatal("callers never reached the cache")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("loader ran %d times, want 1", calls.Load())
+	}
+	for i := range results {
+		if errs[i] != nil || results[i] != "value" {
+			t.Errorf("caller %d got %q, %v; want %q", i, results[i], errs[i], "value")
+		}
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions func(l *lru[int])
+		present []string
+		evicted []string
+	}{
+		{
+			name: "oldest insert goes first",
+			actions: func(l *lru[int]) {
+				l.set("a", 1)
+				l.set("b", 2)
+				l.set("c", 3)
+			},
+			present: []string{"b", "c"},
+			evicted: []string{"a"},
+		},
+		{
+			name: "a read refreshes recency",
+			actions: func(l *lru[int]) {
+				l.set("a", 1)
+				l.set("b", 2)
+				l.get("a")
+				l.set("c", 3)
+			},
+			present: []string{"a", "c"},
+			evicted: []string{"b"},
+		},
+		{
+			name: "an overwrite refreshes recency",
+			actions: func(l *lru[int]) {
+				l.set("a", 1)
+				l.set("b", 2)
+				l.set("a", 10)
+				l.set("c", 3)
+			},
+			present: []string{"a", "c"},
+			evicted: []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLRU[int](2, time.Minute)
+			tt.actions(l)
+
+			for _, key := range tt.present {
+				if _, ok := l.get(key); !ok {
+					t.Errorf("%q was evicted, want present", key)
+				}
+			}
+			for _, key := range tt.evicted {
+				if _, ok := l.get(key); ok {
+					t.Errorf("%q is present, want evicted", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUEntryExpires(t *testing.T) {
+	l := newLRU[int](2, 10*time.Millisecond)
+	l.set("a", 1)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := l.get("a"); ok {
+		t.Error("expired entry was served")
+	}
+	if l.ll.Len() != 0 {
+		t.Errorf("expired entry still held, list length %d", l.ll.Len())
+	}
+}
+
+func TestPeerInvalidationPurgesL1(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     invalidation
+		evicted []string
+		kept    []string
+	}{
+		{
+			name:    "keys",
+			msg:     invalidation{Namespace: "products", Keys: []string{"p1"}},
+			evicted: []string{"p1"},
+			kept:    []string{"p2"},
+		},
+		{
+			name:    "whole namespace",
+			msg:     invalidation{Namespace: "products"},
+			evicted: []string{"p1", "p2"},
+		},
+		{
+			name: "other namespace",
+			msg:  invalidation{Namespace: "sellers"},
+			kept: []string{"p1", "p2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mr := newTestStore(t, configs.CacheConfig{L1Size: 10, L1TTL: time.Minute})
+			c := New[string](store, Options{Namespace: "products"})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			for _, key := range []string{"p1", "p2"} {
+				var calls atomic.Int32
+				if _, err := c.GetOrLoad(ctx, key, countingLoader(&calls, key)); err != nil {
+					t.Fatalf("GetOrLoad(%q): %v", key, err)
+				}
+				if _, ok := c.getLocal(key); !ok {
+					t.Fatalf("%q not in L1 after load", key)
+				}
+			}
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				store.ListenForInvalidations(ctx)
+			}()
+			waitFor(t, func() bool { return mr.PubSubNumSub("test:invalidate")["test:invalidate"] == 1 })
+
+			payload, _ := json.Marshal(tt.msg)
+			mr.Publish("test:invalidate", string(payload))
+
+			// A marker published after the message proves the listener has handled it.
+			marker := invalidation{Namespace: "products", Keys: []string{"marker"}}
+			c.setLocal("marker", entry[string]{Value: "marker"})
+			payload, _ = json.Marshal(marker)
+			mr.Publish("test:invalidate", string(payload))
+			waitFor(t, func() bool {
+				_, ok := c.getLocal("marker")
+				return !ok
+			})
+
+			for _, key := range tt.evicted {
+				if _, ok := c.getLocal(key); ok {
+					t.Errorf("%q still in L1, want purged", key)
+				}
+			}
+			for _, key := range tt.kept {
+				if _, ok := c.getLocal(key); !ok {
+					t.Errorf("%q purged from L1, want kept", key)
+				}
+			}
+
+			cancel()
+			<-done
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+// Store is the shared Redis backend for every typed Cache. It owns TTL defaults, jitter, the
+// namespace version counters used for bulk invalidation, and the pub/sub channel that keeps
+// every replica's in-process L1 consistent. While the Redis circuit is open every Cache bypasses
+// both layers and reads straight from its loader.
+type Store struct {
+	redis               *customRedis.RedisClient
+	client              *redis.Client
+	defaultTTL          time.Duration
+	negativeTTL         time.Duration
+	jitter              float64
+	l1Size              int
+	l1TTL               time.Duration
+	invalidationChannel string
+	log                 *logrus.Logger
+
+	mu       sync.RWMutex
+	evicters map[string][]localEvicter
+}
+
+// localEvicter is implemented by every Cache so broadcast invalidations can reach its L1.
+type localEvicter interface {
+	evictLocal(keys []string)
+}
+
+// invalidation is the pub/sub payload. An empty Keys list means the whole namespace.
+type invalidation struct {
+	Namespace string   `json:"ns"`
+	Keys      []string `json:"keys,omitempty"`
+}
+
+func NewStore(redisClient *customRedis.RedisClient, cfg *configs.CacheConfig, log *logrus.Logger) *Store {
+	s := &Store{
+		redis:               redisClient,
+		client:              redisClient.Client,
+		defaultTTL:          cfg.DefaultTTL,
+		negativeTTL:         cfg.NegativeTTL,
+		jitter:              cfg.TTLJitter,
+		l1Size:              cfg.L1Size,
+		l1TTL:               cfg.L1TTL,
+		invalidationChannel: cfg.InvalidationChannel,
+		log:                 log,
+		evicters:            make(map[string][]localEvicter),
+	}
+
+	// Invalidations published while Redis was down never arrived, so the L1 cannot be trusted
+	// once it comes back.
+	redisClient.OnStateChange(func(from, to customRedis.State) {
+		if to == customRedis.StateClosed {
+			s.purgeLocal()
+		}
+	})
+
+	return s
+}
+
+// InvalidateNamespace bumps the namespace version so every key written under the previous version
+// becomes unreachable at once; the orphaned keys age out through their TTL.
+func (s *Store) InvalidateNamespace(ctx context.Context, namespace string) error {
+	s.evictLocal(namespace, nil)
+
+	if err := s.client.Incr(ctx, versionKey(namespace)).Err(); err != nil {
+		return fmt.Errorf("cache: failed to invalidate namespace %s: %w", namespace, err)
+	}
+
+	s.broadcast(ctx, invalidation{Namespace: namespace})
+	return nil
+}
+
+// ListenForInvalidations applies invalidations broadcast by other replicas to the local L1 caches.
+// It blocks until ctx is cancelled.
+func (s *Store) ListenForInvalidations(ctx context.Context) {
+	pubsub := s.client.Subscribe(ctx, s.invalidationChannel)
+	defer pubsub.Close()
+
+	s.log.WithField("channel", s.invalidationChannel).Info("Listening for cache invalidations")
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var inv invalidation
+			if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+				s.log.WithField("payload", msg.Payload).Warn("Ignoring malformed cache invalidation")
+				continue
+			}
+
+			s.evictLocal(inv.Namespace, inv.Keys)
+		}
+	}
+}
+
+func (s *Store) register(namespace string, cache localEvicter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evicters[namespace] = append(s.evicters[namespace], cache)
+}
+
+func (s *Store) evictLocal(namespace string, keys []string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, cache := range s.evicters[namespace] {
+		cache.evictLocal(keys)
+	}
+}
+
+func (s *Store) purgeLocal() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, caches := range s.evicters {
+		for _, cache := range caches {
+			cache.evictLocal(nil)
+		}
+	}
+}
+
+func (s *Store) available() bool {
+	return s.redis.Healthy()
+}
+
+func (s *Store) broadcast(ctx context.Context, inv invalidation) {
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return
+	}
+
+	if err := s.client.Publish(ctx, s.invalidationChannel, payload).Err(); err != nil {
+		s.log.WithField("namespace", inv.Namespace).WithError(err).Warn("Failed to broadcast cache invalidation")
+	}
+}
+
+func (s *Store) namespaceVersion(ctx context.Context, namespace string) (int64, error) {
+	version, err := s.client.Get(ctx, versionKey(namespace)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cache: failed to read namespace version for %s: %w", namespace, err)
+	}
+
+	return version, nil
+}
+
+// withJitter spreads expiries so keys written together do not all expire in the same instant.
+func (s *Store) withJitter(ttl time.Duration) time.Duration {
+	if s.jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+
+	return ttl + time.Duration(rand.Float64()*s.jitter*float64(ttl))
+}
+
+func versionKey(namespace string) string {
+	return fmt.Sprintf("cache:version:%s", namespace)
+}
@@ -0,0 +1,83 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/tracing"
+)
+
+type MessageHandler func(ctx context.Context, body []byte) error
+
+type RabbitMQConsumer struct {
+	channel *amqp.Channel
+	queue   string
+	tag     string
+	log     *logrus.Logger
+}
+
+func NewRabbitMQConsumer(conn *amqp.Connection, exchange, routingKey, queue string, log *logrus.Logger) (*RabbitMQConsumer, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := ch.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare exchange %s: %w", exchange, err)
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare queue %s: %w", queue, err)
+	}
+
+	if err := ch.QueueBind(queue, routingKey, exchange, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to bind queue %s to %s: %w", queue, exchange, err)
+	}
+
+	return &RabbitMQConsumer{channel: ch, queue: queue, tag: queue + "-consumer", log: log}, nil
+}
+
+// Consume blocks, handing every delivery to handler until Stop is called or the channel is closed.
+// Failed messages are rejected without requeue so a poison message cannot loop forever.
+func (c *RabbitMQConsumer) Consume(handler MessageHandler) error {
+	deliveries, err := c.channel.Consume(c.queue, c.tag, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming %s: %w", c.queue, err)
+	}
+
+	for d := range deliveries {
+		ctx, span := tracing.StartConsume(logctx.ExtractAMQP(context.Background(), d.Headers), c.queue, d)
+
+		err := handler(ctx, d.Body)
+		tracing.EndSpan(span, err)
+
+		if err != nil {
+			logctx.From(ctx, c.log).WithField("queue", c.queue).WithError(err).Error("Message rejected, handler failed")
+			_ = d.Nack(false, false)
+			continue
+		}
+		_ = d.Ack(false)
+	}
+
+	return nil
+}
+
+// Stop cancels the subscription. Deliveries already received are still handled and acked before
+// Consume returns; unacked prefetched messages go back to the queue.
+func (c *RabbitMQConsumer) Stop() error {
+	if err := c.channel.Cancel(c.tag, false); err != nil {
+		return fmt.Errorf("failed to cancel consumer %s: %w", c.tag, err)
+	}
+
+	return nil
+}
+
+func (c *RabbitMQConsumer) Close() {
+	if err := c.channel.Close(); err != nil {
+		c.log.WithError(err).Warn("Failed to close consumer channel")
+	}
+}
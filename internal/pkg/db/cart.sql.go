@@ -0,0 +1,112 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: cart.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteCartItem = `-- name: DeleteCartItem :exec
+DELETE FROM carts
+WHERE user_id = $1 AND product_id = $2
+`
+
+type DeleteCartItemParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (q *Queries) DeleteCartItem(ctx context.Context, arg DeleteCartItemParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCartItem, arg.UserID, arg.ProductID)
+	return err
+}
+
+const getCartItemsByUserID = `-- name: GetCartItemsByUserID :many
+SELECT id, user_id, product_id, quantity, description, created_at, updated_at, checked FROM carts
+WHERE user_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) GetCartItemsByUserID(ctx context.Context, userID uuid.UUID) ([]Cart, error) {
+	rows, err := q.db.QueryContext(ctx, getCartItemsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Cart
+	for rows.Next() {
+		var i Cart
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Checked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCartItem = `-- name: UpsertCartItem :exec
+INSERT INTO carts (
+  id,
+  user_id,
+  product_id,
+  quantity,
+  "description",
+  checked,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (user_id, product_id) DO UPDATE SET
+  quantity = EXCLUDED.quantity,
+  "description" = EXCLUDED."description",
+  checked = EXCLUDED.checked,
+  updated_at = EXCLUDED.updated_at
+`
+
+type UpsertCartItemParams struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	Quantity    int32
+	Description sql.NullString
+	Checked     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) UpsertCartItem(ctx context.Context, arg UpsertCartItemParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCartItem,
+		arg.ID,
+		arg.UserID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.Description,
+		arg.Checked,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -11,21 +11,96 @@ import (
 	"github.com/google/uuid"
 )
 
-type Product struct {
+type Cart struct {
 	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	Quantity    int32
 	Description sql.NullString
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
-	DeletedAt   sql.NullTime
+	Checked     bool
+}
+
+type Product struct {
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    sql.NullTime
+	Status       string
+	StatusReason sql.NullString
+	ReviewedBy   uuid.NullUUID
+	ReviewedAt   sql.NullTime
+	RatingCount  int32
+	RatingSum    int32
+	RatingAvg    float64
+}
+
+type ProductAnswer struct {
+	ID            uuid.UUID
+	QuestionID    uuid.UUID
+	ResponderID   uuid.UUID
+	ResponderRole string
+	Body          string
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type ProductDailyStat struct {
+	ProductID     uuid.UUID
+	SellerID      uuid.UUID
+	StatDate      time.Time
+	Views         int32
+	UniqueViewers int32
+	AddToCarts    int32
+	Orders        int32
+	UnitsSold     int32
+	UpdatedAt     time.Time
+}
+
+type ProductQuestion struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	AskerID   uuid.UUID
+	Body      string
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ProductReview struct {
+	ID               uuid.UUID
+	ProductID        uuid.UUID
+	UserID           uuid.UUID
+	Rating           int16
+	Body             sql.NullString
+	VerifiedPurchase bool
+	SellerReply      sql.NullString
+	SellerRepliedAt  sql.NullTime
+	ReportCount      int32
+	Hidden           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type ReviewReport struct {
+	ReviewID   uuid.UUID
+	ReporterID uuid.UUID
+	Reason     string
+	CreatedAt  time.Time
 }
 
-type User struct {
-	ID   uuid.UUID
-	Name string
+type VerifiedPurchase struct {
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	OrderID     string
+	PurchasedAt time.Time
 }
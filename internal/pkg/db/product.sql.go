@@ -14,6 +14,52 @@ import (
 	"github.com/lib/pq"
 )
 
+const adjustProductRating = `-- name: AdjustProductRating :one
+UPDATE products
+SET
+    rating_count = rating_count + $1,
+    rating_sum = rating_sum + $2,
+    rating_avg = CASE
+        WHEN rating_count + $1 > 0
+        THEN (rating_sum + $2)::float8 / (rating_count + $1)
+        ELSE 0
+    END
+WHERE id = $3
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
+`
+
+type AdjustProductRatingParams struct {
+	CountDelta int32
+	SumDelta   int32
+	ProductID  uuid.UUID
+}
+
+func (q *Queries) AdjustProductRating(ctx context.Context, arg AdjustProductRatingParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, adjustProductRating, arg.CountDelta, arg.SumDelta, arg.ProductID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Price,
+		&i.Stock,
+		&i.Discount,
+		&i.Type,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
+	)
+	return i, err
+}
+
 const decreaseProductStock = `-- name: DecreaseProductStock :one
 UPDATE products
 SET
@@ -21,7 +67,7 @@ SET
 WHERE
     id = $2
     AND stock >= $1 -- Penjaga anti-overselling
-RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 type DecreaseProductStockParams struct {
@@ -44,13 +90,20 @@ func (q *Queries) DecreaseProductStock(ctx context.Context, arg DecreaseProductS
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
 
 const deleteProduct = `-- name: DeleteProduct :one
 DELETE FROM products WHERE id = $1 
-RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) (Product, error) {
@@ -68,6 +121,13 @@ func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) (Product, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
@@ -82,23 +142,31 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE deleted_at IS NULL
+WHERE deleted_at IS NULL AND status = 'published'
 `
 
 type GetAllProductsRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetAllProducts(ctx context.Context) ([]GetAllProductsRow, error) {
@@ -119,6 +187,10 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]GetAllProductsRow, erro
 			&i.Discount,
 			&i.Type,
 			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -145,6 +217,10 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
@@ -152,16 +228,20 @@ WHERE id = $1 AND deleted_at IS NULL
 `
 
 type GetProductByIDRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductByIDRow, error) {
@@ -176,6 +256,10 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductB
 		&i.Discount,
 		&i.Type,
 		&i.Description,
+		&i.Status,
+		&i.StatusReason,
+		&i.RatingAvg,
+		&i.RatingCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -192,23 +276,31 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL AND status = 'published'
 `
 
 type GetProductByIDsRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetProductByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]GetProductByIDsRow, error) {
@@ -229,6 +321,10 @@ func (q *Queries) GetProductByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]
 			&i.Discount,
 			&i.Type,
 			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -266,23 +362,31 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE name ILIKE $1 AND deleted_at IS NULL
+WHERE name ILIKE $1 AND deleted_at IS NULL AND status = 'published'
 `
 
 type GetProductsByNameRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetProductsByName(ctx context.Context, name string) ([]GetProductsByNameRow, error) {
@@ -303,6 +407,10 @@ func (q *Queries) GetProductsByName(ctx context.Context, name string) ([]GetProd
 			&i.Discount,
 			&i.Type,
 			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -329,23 +437,31 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE seller_id = $1 AND deleted_at IS NULL
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published'
 `
 
 type GetProductsBySellerIDRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]GetProductsBySellerIDRow, error) {
@@ -366,6 +482,256 @@ func (q *Queries) GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID)
 			&i.Discount,
 			&i.Type,
 			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsBySellerIDAnyStatus = `-- name: GetProductsBySellerIDAnyStatus :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL
+ORDER BY updated_at DESC
+`
+
+type GetProductsBySellerIDAnyStatusRow struct {
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (q *Queries) GetProductsBySellerIDAnyStatus(ctx context.Context, sellerID uuid.UUID) ([]GetProductsBySellerIDAnyStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductsBySellerIDAnyStatus, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductsBySellerIDAnyStatusRow
+	for rows.Next() {
+		var i GetProductsBySellerIDAnyStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Price,
+			&i.Stock,
+			&i.Discount,
+			&i.Type,
+			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsBySellerIDPaged = `-- name: GetProductsBySellerIDPaged :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published'
+ORDER BY
+  CASE WHEN $2::text = 'rating' THEN rating_avg END DESC,
+  CASE WHEN $2::text = 'rating' THEN rating_count END DESC,
+  CASE WHEN $2::text = 'price_asc' THEN price END ASC,
+  CASE WHEN $2::text = 'price_desc' THEN price END DESC,
+  created_at DESC
+LIMIT $4 OFFSET $3
+`
+
+type GetProductsBySellerIDPagedParams struct {
+	SellerID   uuid.UUID
+	Sort       string
+	PageOffset int32
+	PageLimit  int32
+}
+
+type GetProductsBySellerIDPagedRow struct {
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (q *Queries) GetProductsBySellerIDPaged(ctx context.Context, arg GetProductsBySellerIDPagedParams) ([]GetProductsBySellerIDPagedRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductsBySellerIDPaged,
+		arg.SellerID,
+		arg.Sort,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductsBySellerIDPagedRow
+	for rows.Next() {
+		var i GetProductsBySellerIDPagedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Price,
+			&i.Stock,
+			&i.Discount,
+			&i.Type,
+			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsByStatus = `-- name: GetProductsByStatus :many
+SELECT 
+  id,
+  seller_id,
+  "name",
+  price,
+  stock,
+  discount,
+  "type",
+  "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
+  created_at,
+  updated_at
+FROM products
+WHERE status = $1 AND deleted_at IS NULL
+ORDER BY updated_at ASC
+`
+
+type GetProductsByStatusRow struct {
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (q *Queries) GetProductsByStatus(ctx context.Context, status string) ([]GetProductsByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductsByStatusRow
+	for rows.Next() {
+		var i GetProductsByStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Name,
+			&i.Price,
+			&i.Stock,
+			&i.Discount,
+			&i.Type,
+			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -392,23 +758,31 @@ SELECT
   discount,
   "type",
   "description",
+  status,
+  status_reason,
+  rating_avg,
+  rating_count,
   created_at,
   updated_at
 FROM products
-WHERE "type" = $1 AND deleted_at IS NULL
+WHERE "type" = $1 AND deleted_at IS NULL AND status = 'published'
 `
 
 type GetProductsByTypeRow struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	Name        string
-	Price       int32
-	Stock       int32
-	Discount    sql.NullInt32
-	Type        sql.NullString
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	SellerID     uuid.UUID
+	Name         string
+	Price        int32
+	Stock        int32
+	Discount     sql.NullInt32
+	Type         sql.NullString
+	Description  sql.NullString
+	Status       string
+	StatusReason sql.NullString
+	RatingAvg    float64
+	RatingCount  int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (q *Queries) GetProductsByType(ctx context.Context, type_ sql.NullString) ([]GetProductsByTypeRow, error) {
@@ -429,6 +803,10 @@ func (q *Queries) GetProductsByType(ctx context.Context, type_ sql.NullString) (
 			&i.Discount,
 			&i.Type,
 			&i.Description,
+			&i.Status,
+			&i.StatusReason,
+			&i.RatingAvg,
+			&i.RatingCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -445,13 +823,73 @@ func (q *Queries) GetProductsByType(ctx context.Context, type_ sql.NullString) (
 	return items, nil
 }
 
+const getSellerCategories = `-- name: GetSellerCategories :many
+SELECT
+  "type"::text AS "type",
+  COUNT(*)::int AS product_count
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published' AND "type" IS NOT NULL AND "type" <> ''
+GROUP BY "type"
+ORDER BY product_count DESC, "type"
+`
+
+type GetSellerCategoriesRow struct {
+	Type         string
+	ProductCount int32
+}
+
+func (q *Queries) GetSellerCategories(ctx context.Context, sellerID uuid.UUID) ([]GetSellerCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSellerCategories, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSellerCategoriesRow
+	for rows.Next() {
+		var i GetSellerCategoriesRow
+		if err := rows.Scan(&i.Type, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSellerProductStats = `-- name: GetSellerProductStats :one
+SELECT
+  COUNT(*)::int AS product_count,
+  COALESCE(SUM(rating_count), 0)::int AS rating_count,
+  COALESCE(SUM(rating_sum), 0)::int AS rating_sum
+FROM products
+WHERE seller_id = $1 AND deleted_at IS NULL AND status = 'published'
+`
+
+type GetSellerProductStatsRow struct {
+	ProductCount int32
+	RatingCount  int32
+	RatingSum    int32
+}
+
+func (q *Queries) GetSellerProductStats(ctx context.Context, sellerID uuid.UUID) (GetSellerProductStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getSellerProductStats, sellerID)
+	var i GetSellerProductStatsRow
+	err := row.Scan(&i.ProductCount, &i.RatingCount, &i.RatingSum)
+	return i, err
+}
+
 const increaseProductStock = `-- name: IncreaseProductStock :one
 UPDATE products
 SET
     stock = stock + $1
 WHERE
     id = $2
-RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 type IncreaseProductStockParams struct {
@@ -474,6 +912,13 @@ func (q *Queries) IncreaseProductStock(ctx context.Context, arg IncreaseProductS
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
@@ -488,11 +933,12 @@ INSERT INTO products (
   discount, 
   "type", 
   "description", 
+  status,
   created_at, 
   updated_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()
-) RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()
+) RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 type InsertProductParams struct {
@@ -504,6 +950,7 @@ type InsertProductParams struct {
 	Discount    sql.NullInt32
 	Type        sql.NullString
 	Description sql.NullString
+	Status      string
 }
 
 func (q *Queries) InsertProduct(ctx context.Context, arg InsertProductParams) (Product, error) {
@@ -516,6 +963,7 @@ func (q *Queries) InsertProduct(ctx context.Context, arg InsertProductParams) (P
 		arg.Discount,
 		arg.Type,
 		arg.Description,
+		arg.Status,
 	)
 	var i Product
 	err := row.Scan(
@@ -530,15 +978,54 @@ func (q *Queries) InsertProduct(ctx context.Context, arg InsertProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
 
+const rebuildProductRatings = `-- name: RebuildProductRatings :execrows
+UPDATE products p
+SET
+    rating_count = agg.rating_count,
+    rating_sum = agg.rating_sum,
+    rating_avg = CASE
+        WHEN agg.rating_count > 0
+        THEN agg.rating_sum::float8 / agg.rating_count
+        ELSE 0
+    END
+FROM (
+    SELECT
+        pr.id,
+        COUNT(r.id)::int AS rating_count,
+        COALESCE(SUM(r.rating), 0)::int AS rating_sum
+    FROM products pr
+    LEFT JOIN product_reviews r ON r.product_id = pr.id AND r.hidden = FALSE
+    GROUP BY pr.id
+) agg
+WHERE p.id = agg.id
+  AND (p.rating_count <> agg.rating_count OR p.rating_sum <> agg.rating_sum)
+`
+
+// Recomputes the rating aggregates from visible product_reviews, touching only rows that drifted.
+func (q *Queries) RebuildProductRatings(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, rebuildProductRatings)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products
 SET name = $2, price = $3, stock = $4, discount = $5, type = $6, description = $7, updated_at = NOW()
 WHERE id = $1 AND seller_id = $8
-RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 type UpdateProductParams struct {
@@ -576,13 +1063,67 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
+	)
+	return i, err
+}
+
+const updateProductStatus = `-- name: UpdateProductStatus :one
+UPDATE products
+SET status = $2, status_reason = $3, reviewed_by = $4, reviewed_at = $5, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
+`
+
+type UpdateProductStatusParams struct {
+	ID           uuid.UUID
+	Status       string
+	StatusReason sql.NullString
+	ReviewedBy   uuid.NullUUID
+	ReviewedAt   sql.NullTime
+}
+
+func (q *Queries) UpdateProductStatus(ctx context.Context, arg UpdateProductStatusParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, updateProductStatus,
+		arg.ID,
+		arg.Status,
+		arg.StatusReason,
+		arg.ReviewedBy,
+		arg.ReviewedAt,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Name,
+		&i.Price,
+		&i.Stock,
+		&i.Discount,
+		&i.Type,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
 
 const updateProductStock = `-- name: UpdateProductStock :one
 UPDATE products SET stock = $2 WHERE id = $1 
-RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at
+RETURNING id, seller_id, name, price, stock, discount, type, description, created_at, updated_at, deleted_at, status, status_reason, reviewed_by, reviewed_at, rating_count, rating_sum, rating_avg
 `
 
 type UpdateProductStockParams struct {
@@ -605,6 +1146,13 @@ func (q *Queries) UpdateProductStock(ctx context.Context, arg UpdateProductStock
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingAvg,
 	)
 	return i, err
 }
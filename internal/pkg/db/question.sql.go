@@ -0,0 +1,340 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: question.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const countQuestionsByProductID = `-- name: CountQuestionsByProductID :one
+SELECT COUNT(*) FROM product_questions
+WHERE product_id = $1 AND status = 'visible'
+`
+
+func (q *Queries) CountQuestionsByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuestionsByProductID, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countQuestionsByStatus = `-- name: CountQuestionsByStatus :one
+SELECT COUNT(*) FROM product_questions
+WHERE status = $1
+`
+
+func (q *Queries) CountQuestionsByStatus(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuestionsByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getQuestionByID = `-- name: GetQuestionByID :one
+SELECT id, product_id, asker_id, body, status, created_at, updated_at FROM product_questions
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestionByID(ctx context.Context, id uuid.UUID) (ProductQuestion, error) {
+	row := q.db.QueryRowContext(ctx, getQuestionByID, id)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AskerID,
+		&i.Body,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getQuestionsByProductID = `-- name: GetQuestionsByProductID :many
+SELECT id, product_id, asker_id, body, status, created_at, updated_at FROM product_questions
+WHERE product_id = $1 AND status = 'visible'
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetQuestionsByProductIDParams struct {
+	ProductID  uuid.UUID
+	PageOffset int32
+	PageLimit  int32
+}
+
+func (q *Queries) GetQuestionsByProductID(ctx context.Context, arg GetQuestionsByProductIDParams) ([]ProductQuestion, error) {
+	rows, err := q.db.QueryContext(ctx, getQuestionsByProductID, arg.ProductID, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductQuestion
+	for rows.Next() {
+		var i ProductQuestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.AskerID,
+			&i.Body,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionsByStatus = `-- name: GetQuestionsByStatus :many
+SELECT id, product_id, asker_id, body, status, created_at, updated_at FROM product_questions
+WHERE status = $1
+ORDER BY created_at ASC
+LIMIT $3 OFFSET $2
+`
+
+type GetQuestionsByStatusParams struct {
+	Status     string
+	PageOffset int32
+	PageLimit  int32
+}
+
+func (q *Queries) GetQuestionsByStatus(ctx context.Context, arg GetQuestionsByStatusParams) ([]ProductQuestion, error) {
+	rows, err := q.db.QueryContext(ctx, getQuestionsByStatus, arg.Status, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductQuestion
+	for rows.Next() {
+		var i ProductQuestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.AskerID,
+			&i.Body,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVisibleAnswersByQuestionIDs = `-- name: GetVisibleAnswersByQuestionIDs :many
+SELECT id, question_id, responder_id, responder_role, body, status, created_at, updated_at FROM product_answers
+WHERE question_id = ANY($1::uuid[]) AND status = 'visible'
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetVisibleAnswersByQuestionIDs(ctx context.Context, questionIds []uuid.UUID) ([]ProductAnswer, error) {
+	rows, err := q.db.QueryContext(ctx, getVisibleAnswersByQuestionIDs, pq.Array(questionIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductAnswer
+	for rows.Next() {
+		var i ProductAnswer
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.ResponderID,
+			&i.ResponderRole,
+			&i.Body,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertAnswer = `-- name: InsertAnswer :one
+INSERT INTO product_answers (
+  id,
+  question_id,
+  responder_id,
+  responder_role,
+  body,
+  status,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, NOW(), NOW()
+) RETURNING id, question_id, responder_id, responder_role, body, status, created_at, updated_at
+`
+
+type InsertAnswerParams struct {
+	ID            uuid.UUID
+	QuestionID    uuid.UUID
+	ResponderID   uuid.UUID
+	ResponderRole string
+	Body          string
+	Status        string
+}
+
+func (q *Queries) InsertAnswer(ctx context.Context, arg InsertAnswerParams) (ProductAnswer, error) {
+	row := q.db.QueryRowContext(ctx, insertAnswer,
+		arg.ID,
+		arg.QuestionID,
+		arg.ResponderID,
+		arg.ResponderRole,
+		arg.Body,
+		arg.Status,
+	)
+	var i ProductAnswer
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.ResponderID,
+		&i.ResponderRole,
+		&i.Body,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertQuestion = `-- name: InsertQuestion :one
+INSERT INTO product_questions (
+  id,
+  product_id,
+  asker_id,
+  body,
+  status,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, NOW(), NOW()
+) RETURNING id, product_id, asker_id, body, status, created_at, updated_at
+`
+
+type InsertQuestionParams struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	AskerID   uuid.UUID
+	Body      string
+	Status    string
+}
+
+func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams) (ProductQuestion, error) {
+	row := q.db.QueryRowContext(ctx, insertQuestion,
+		arg.ID,
+		arg.ProductID,
+		arg.AskerID,
+		arg.Body,
+		arg.Status,
+	)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AskerID,
+		&i.Body,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateAnswerStatus = `-- name: UpdateAnswerStatus :one
+WITH previous AS (
+    SELECT p.id, p.status FROM product_answers AS p WHERE p.id = $2 FOR UPDATE
+)
+UPDATE product_answers AS a
+SET status = $1, updated_at = NOW()
+FROM previous
+WHERE a.id = previous.id
+RETURNING a.id, a.question_id, a.responder_id, a.responder_role, a.body, a.status, a.created_at, a.updated_at, previous.status AS previous_status
+`
+
+type UpdateAnswerStatusParams struct {
+	Status string
+	ID     uuid.UUID
+}
+
+type UpdateAnswerStatusRow struct {
+	ProductAnswer  ProductAnswer
+	PreviousStatus string
+}
+
+// The previous status is read under the row lock, so concurrent moderators agree on which of
+// them made the answer visible.
+func (q *Queries) UpdateAnswerStatus(ctx context.Context, arg UpdateAnswerStatusParams) (UpdateAnswerStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, updateAnswerStatus, arg.Status, arg.ID)
+	var i UpdateAnswerStatusRow
+	err := row.Scan(
+		&i.ProductAnswer.ID,
+		&i.ProductAnswer.QuestionID,
+		&i.ProductAnswer.ResponderID,
+		&i.ProductAnswer.ResponderRole,
+		&i.ProductAnswer.Body,
+		&i.ProductAnswer.Status,
+		&i.ProductAnswer.CreatedAt,
+		&i.ProductAnswer.UpdatedAt,
+		&i.PreviousStatus,
+	)
+	return i, err
+}
+
+const updateQuestionStatus = `-- name: UpdateQuestionStatus :one
+UPDATE product_questions
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, product_id, asker_id, body, status, created_at, updated_at
+`
+
+type UpdateQuestionStatusParams struct {
+	ID     uuid.UUID
+	Status string
+}
+
+func (q *Queries) UpdateQuestionStatus(ctx context.Context, arg UpdateQuestionStatusParams) (ProductQuestion, error) {
+	row := q.db.QueryRowContext(ctx, updateQuestionStatus, arg.ID, arg.Status)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AskerID,
+		&i.Body,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,431 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: review.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const countReviewsByProductID = `-- name: CountReviewsByProductID :one
+SELECT COUNT(*) FROM product_reviews
+WHERE product_id = $1 AND hidden = FALSE
+`
+
+func (q *Queries) CountReviewsByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countReviewsByProductID, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteReview = `-- name: DeleteReview :one
+DELETE FROM product_reviews
+WHERE id = $1
+RETURNING id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at
+`
+
+func (q *Queries) DeleteReview(ctx context.Context, id uuid.UUID) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, deleteReview, id)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReviewByID = `-- name: GetReviewByID :one
+SELECT id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at FROM product_reviews
+WHERE id = $1
+`
+
+func (q *Queries) GetReviewByID(ctx context.Context, id uuid.UUID) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, getReviewByID, id)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReviewsByProductID = `-- name: GetReviewsByProductID :many
+SELECT id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at FROM product_reviews
+WHERE product_id = $1 AND hidden = FALSE
+ORDER BY
+  CASE WHEN $2::text = 'highest' THEN rating END DESC,
+  CASE WHEN $2::text = 'lowest' THEN rating END ASC,
+  created_at DESC
+LIMIT $4 OFFSET $3
+`
+
+type GetReviewsByProductIDParams struct {
+	ProductID  uuid.UUID
+	Sort       string
+	PageOffset int32
+	PageLimit  int32
+}
+
+func (q *Queries) GetReviewsByProductID(ctx context.Context, arg GetReviewsByProductIDParams) ([]ProductReview, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewsByProductID,
+		arg.ProductID,
+		arg.Sort,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductReview
+	for rows.Next() {
+		var i ProductReview
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.UserID,
+			&i.Rating,
+			&i.Body,
+			&i.VerifiedPurchase,
+			&i.SellerReply,
+			&i.SellerRepliedAt,
+			&i.ReportCount,
+			&i.Hidden,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasVerifiedPurchase = `-- name: HasVerifiedPurchase :one
+SELECT EXISTS (
+  SELECT 1 FROM verified_purchases
+  WHERE user_id = $1 AND product_id = $2
+)
+`
+
+type HasVerifiedPurchaseParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (q *Queries) HasVerifiedPurchase(ctx context.Context, arg HasVerifiedPurchaseParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasVerifiedPurchase, arg.UserID, arg.ProductID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const incrementReviewReportCount = `-- name: IncrementReviewReportCount :one
+UPDATE product_reviews
+SET
+  report_count = report_count + 1,
+  hidden = hidden OR report_count + 1 >= $1::int
+WHERE id = $2
+RETURNING id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at
+`
+
+type IncrementReviewReportCountParams struct {
+	HideThreshold int32
+	ID            uuid.UUID
+}
+
+func (q *Queries) IncrementReviewReportCount(ctx context.Context, arg IncrementReviewReportCountParams) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, incrementReviewReportCount, arg.HideThreshold, arg.ID)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertReview = `-- name: InsertReview :one
+INSERT INTO product_reviews (
+  id,
+  product_id,
+  user_id,
+  rating,
+  body,
+  verified_purchase,
+  created_at,
+  updated_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, NOW(), NOW()
+) RETURNING id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at
+`
+
+type InsertReviewParams struct {
+	ID               uuid.UUID
+	ProductID        uuid.UUID
+	UserID           uuid.UUID
+	Rating           int16
+	Body             sql.NullString
+	VerifiedPurchase bool
+}
+
+func (q *Queries) InsertReview(ctx context.Context, arg InsertReviewParams) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, insertReview,
+		arg.ID,
+		arg.ProductID,
+		arg.UserID,
+		arg.Rating,
+		arg.Body,
+		arg.VerifiedPurchase,
+	)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertReviewReport = `-- name: InsertReviewReport :execrows
+INSERT INTO review_reports (
+  review_id,
+  reporter_id,
+  reason,
+  created_at
+) VALUES (
+  $1, $2, $3, NOW()
+) ON CONFLICT (review_id, reporter_id) DO NOTHING
+`
+
+type InsertReviewReportParams struct {
+	ReviewID   uuid.UUID
+	ReporterID uuid.UUID
+	Reason     string
+}
+
+func (q *Queries) InsertReviewReport(ctx context.Context, arg InsertReviewReportParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertReviewReport, arg.ReviewID, arg.ReporterID, arg.Reason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertVerifiedPurchase = `-- name: InsertVerifiedPurchase :exec
+INSERT INTO verified_purchases (
+  user_id,
+  product_id,
+  order_id,
+  purchased_at
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (user_id, product_id) DO NOTHING
+`
+
+type InsertVerifiedPurchaseParams struct {
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	OrderID     string
+	PurchasedAt time.Time
+}
+
+func (q *Queries) InsertVerifiedPurchase(ctx context.Context, arg InsertVerifiedPurchaseParams) error {
+	_, err := q.db.ExecContext(ctx, insertVerifiedPurchase,
+		arg.UserID,
+		arg.ProductID,
+		arg.OrderID,
+		arg.PurchasedAt,
+	)
+	return err
+}
+
+const lockReviewByID = `-- name: LockReviewByID :one
+SELECT id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at FROM product_reviews
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockReviewByID(ctx context.Context, id uuid.UUID) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, lockReviewByID, id)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const lockReviewByUserAndProduct = `-- name: LockReviewByUserAndProduct :one
+SELECT id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at FROM product_reviews
+WHERE user_id = $1 AND product_id = $2
+FOR UPDATE
+`
+
+type LockReviewByUserAndProductParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (q *Queries) LockReviewByUserAndProduct(ctx context.Context, arg LockReviewByUserAndProductParams) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, lockReviewByUserAndProduct, arg.UserID, arg.ProductID)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markReviewsVerified = `-- name: MarkReviewsVerified :exec
+UPDATE product_reviews
+SET verified_purchase = TRUE
+WHERE user_id = $1 AND product_id = ANY($2::uuid[])
+`
+
+type MarkReviewsVerifiedParams struct {
+	UserID     uuid.UUID
+	ProductIds []uuid.UUID
+}
+
+func (q *Queries) MarkReviewsVerified(ctx context.Context, arg MarkReviewsVerifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markReviewsVerified, arg.UserID, pq.Array(arg.ProductIds))
+	return err
+}
+
+const updateReview = `-- name: UpdateReview :one
+UPDATE product_reviews
+SET rating = $2, body = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at
+`
+
+type UpdateReviewParams struct {
+	ID     uuid.UUID
+	Rating int16
+	Body   sql.NullString
+}
+
+func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, updateReview, arg.ID, arg.Rating, arg.Body)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateReviewReply = `-- name: UpdateReviewReply :one
+UPDATE product_reviews
+SET seller_reply = $2, seller_replied_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING id, product_id, user_id, rating, body, verified_purchase, seller_reply, seller_replied_at, report_count, hidden, created_at, updated_at
+`
+
+type UpdateReviewReplyParams struct {
+	ID          uuid.UUID
+	SellerReply sql.NullString
+}
+
+func (q *Queries) UpdateReviewReply(ctx context.Context, arg UpdateReviewReplyParams) (ProductReview, error) {
+	row := q.db.QueryRowContext(ctx, updateReviewReply, arg.ID, arg.SellerReply)
+	var i ProductReview
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Body,
+		&i.VerifiedPurchase,
+		&i.SellerReply,
+		&i.SellerRepliedAt,
+		&i.ReportCount,
+		&i.Hidden,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
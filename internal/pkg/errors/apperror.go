@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kind classifies an error and decides how it is reported over HTTP and gRPC.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindUnauthenticated
+	KindForbidden
+	KindNotFound
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindUnavailable
+	KindResourceExhausted
+)
+
+// HTTPStatus returns the HTTP status code for k.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindInvalidArgument:
+		return http.StatusBadRequest
+	case KindUnauthenticated:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindAlreadyExists, KindFailedPrecondition:
+		return http.StatusConflict
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode returns the gRPC status code for k.
+func (k Kind) GRPCCode() codes.Code {
+	switch k {
+	case KindInvalidArgument:
+		return codes.InvalidArgument
+	case KindUnauthenticated:
+		return codes.Unauthenticated
+	case KindForbidden:
+		return codes.PermissionDenied
+	case KindNotFound:
+		return codes.NotFound
+	case KindAlreadyExists:
+		return codes.AlreadyExists
+	case KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case KindUnavailable:
+		return codes.Unavailable
+	case KindResourceExhausted:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// FieldError describes why a single request field was rejected. Rule and Param identify the
+// failed constraint so the message can be localized; Message is the English fallback.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Param   string
+	Message string
+}
+
+// Error is a domain error. Message is safe to show to clients; the wrapped cause is not.
+//
+// Errors derived with Withf, WithDetails or Wrap still match their sentinel with errors.Is.
+type Error struct {
+	Code    string
+	Kind    Kind
+	Message string
+	Details []FieldError
+
+	cause  error
+	parent *Error
+}
+
+// New declares a sentinel error. code is a stable, machine-readable identifier.
+func New(code string, kind Kind, message string) *Error {
+	return &Error{Code: code, Kind: kind, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches e against the sentinel it was derived from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.root() == e.root()
+}
+
+// Withf returns a copy of e whose message is extended with a client-safe explanation.
+func (e *Error) Withf(format string, args ...any) *Error {
+	derived := e.derive()
+	derived.Message = e.Message + ": " + fmt.Sprintf(format, args...)
+	return derived
+}
+
+// WithDetails returns a copy of e carrying field-level validation details.
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	derived := e.derive()
+	derived.Details = append(append([]FieldError(nil), e.Details...), details...)
+	return derived
+}
+
+// Wrap returns a copy of e recording the underlying cause for logs.
+func (e *Error) Wrap(cause error) *Error {
+	derived := e.derive()
+	derived.cause = cause
+	return derived
+}
+
+func (e *Error) derive() *Error {
+	derived := *e
+	derived.parent = e.root()
+	return &derived
+}
+
+func (e *Error) root() *Error {
+	if e.parent != nil {
+		return e.parent
+	}
+	return e
+}
+
+// Invalid is shorthand for a payload error on a single field.
+func Invalid(field, rule, message string) *Error {
+	return ErrInvalidRequestPayload.WithDetails(FieldError{Field: field, Rule: rule, Message: message})
+}
+
+// From returns the domain error in err's chain, treating anything else as internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return ErrInternalServerError.Wrap(err)
+}
+
+// GRPCStatus converts err into a gRPC status error. Internal causes are not sent to the caller.
+func GRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok && !isDomain(err) {
+		return err
+	}
+
+	appErr := From(err)
+	return status.Error(appErr.Kind.GRPCCode(), appErr.Message)
+}
+
+func isDomain(err error) bool {
+	var appErr *Error
+	return errors.As(err, &appErr)
+}
@@ -1,33 +1,52 @@
 package errors
 
-import "errors"
-
 var (
-	ErrInvalidRequestPayload = errors.New("invalid request payload")
+	ErrInvalidRequestPayload = New("invalid_request_payload", KindInvalidArgument, "invalid request payload")
+
+	ErrInternalServerError = New("internal_error", KindInternal, "internal server error")
+
+	ErrInsufficientStock           = New("insufficient_stock", KindFailedPrecondition, "insufficient stock for this quantity")
+	ErrInvalidUserInput            = New("invalid_user_input", KindInvalidArgument, "invalid user input")
+	ErrProductNotBelongToSeller    = New("product_not_owned", KindForbidden, "product does not belong to this seller")
+	ErrInvalidProductUpdatePayload = New("invalid_product_update_payload", KindInvalidArgument, "all required columns must not be empty and valid for update")
+	ErrProductOutOfStock           = New("product_out_of_stock", KindFailedPrecondition, "product out of stock")
+	ErrProductNotFound             = New("product_not_found", KindNotFound, "product not found")
 
-	ErrInternalServerError = errors.New("internal server error")
+	ErrInvalidStatusTransition = New("invalid_status_transition", KindFailedPrecondition, "product status transition is not allowed")
+	ErrInvalidProductStatus    = New("invalid_product_status", KindInvalidArgument, "invalid product status")
 
-	ErrInsufficientStock           = errors.New("insufficient stock for this quantity")
-	ErrInvalidUserInput            = errors.New("invalid user input")
-	ErrProductNotBelongToSeller    = errors.New("product does not belong to this seller")
-	ErrInvalidProductUpdatePayload = errors.New("all required columns must not be empty and valid for update")
-	ErrProductOutOfStock           = errors.New("product out of stock")
+	ErrReviewNotFound         = New("review_not_found", KindNotFound, "review not found")
+	ErrReviewAlreadyExists    = New("review_already_exists", KindAlreadyExists, "you have already reviewed this product")
+	ErrReviewAlreadyReported  = New("review_already_reported", KindAlreadyExists, "you have already reported this review")
+	ErrCannotReviewOwnProduct = New("cannot_review_own_product", KindForbidden, "sellers cannot review their own products")
 
-	ErrCartNotFound          = errors.New("cart item not found")
-	ErrInvalidCartOperation  = errors.New("invalid cart operation")
-	ErrCartAlreadyCheckedOut = errors.New("cart is already checked out")
-	ErrCartRetrievalFail     = errors.New("failed to retrieve cart")
-	ErrCartEmpty             = errors.New("cart is empty")
+	ErrQuestionNotFound     = New("question_not_found", KindNotFound, "question not found")
+	ErrAnswerNotFound       = New("answer_not_found", KindNotFound, "answer not found")
+	ErrInvalidContentStatus = New("invalid_content_status", KindInvalidArgument, "invalid content status")
+
+	ErrSellerNotFound = New("seller_not_found", KindNotFound, "seller not found")
+
+	ErrCartNotFound          = New("cart_not_found", KindNotFound, "cart item not found")
+	ErrInvalidCartOperation  = New("invalid_cart_operation", KindInvalidArgument, "invalid cart operation")
+	ErrCartAlreadyCheckedOut = New("cart_already_checked_out", KindFailedPrecondition, "cart is already checked out")
+	ErrCartRetrievalFail     = New("cart_retrieval_failed", KindInternal, "failed to retrieve cart")
+	ErrCartEmpty             = New("cart_empty", KindFailedPrecondition, "cart is empty")
+	ErrCartUnavailable       = New("cart_unavailable", KindUnavailable, "cart is temporarily unavailable, please try again shortly")
 
 	MsgFailedToClearProductCaches = "failed to clear product cache"
 	MsgProductCacheCleared        = "product cache cleared"
 
-	ErrCartItemNotFound = errors.New("cart item not found")
+	ErrCartItemNotFound = New("cart_item_not_found", KindNotFound, "cart item not found")
+
+	ErrNotFound = New("not_found", KindNotFound, "not found")
 
-	ErrNotFound = errors.New("not found")
+	ErrOrderNotFound = New("order_not_found", KindNotFound, "order not found")
 
-	ErrOrderNotFound = errors.New("order not found")
+	ErrInvalidUserSession = New("invalid_user_session", KindUnauthenticated, "invalid user session")
+	ErrUnauthorized       = New("unauthorized", KindUnauthenticated, "unauthorized")
+	ErrInvalidToken       = New("invalid_token", KindUnauthenticated, "invalid or expired token")
+	ErrAccessDenied       = New("access_denied", KindForbidden, "access denied")
+	ErrAuthUnavailable    = New("auth_unavailable", KindUnavailable, "authentication is temporarily unavailable, please try again shortly")
 
-	ErrInvalidUserSession = errors.New("invalid user session")
-	ErrUnauthorized       = errors.New("unauthorized")
+	ErrRateLimited = New("rate_limited", KindResourceExhausted, "too many requests, please slow down")
 )
@@ -0,0 +1,56 @@
+package errors
+
+import "strings"
+
+// DefaultLanguage is used when the client asks for a language without a catalog.
+const DefaultLanguage = "en"
+
+// fieldMessages holds the client-facing text per language and rule; %s is the rule parameter.
+var fieldMessages = map[string]map[string]string{
+	"en": {
+		"required": "is required",
+		"min":      "must be at least %s",
+		"max":      "must be at most %s",
+		"gt":       "must be greater than %s",
+		"gte":      "must be greater than or equal to %s",
+		"lte":      "must be less than or equal to %s",
+		"oneof":    "must be one of: %s",
+		"uuid":     "must be a valid UUID",
+		"date":     "must be formatted as YYYY-MM-DD",
+		"ltefield": "must not be after '%s'",
+	},
+	"id": {
+		"required": "wajib diisi",
+		"min":      "minimal %s",
+		"max":      "maksimal %s",
+		"gt":       "harus lebih dari %s",
+		"gte":      "harus lebih dari atau sama dengan %s",
+		"lte":      "harus kurang dari atau sama dengan %s",
+		"oneof":    "harus salah satu dari: %s",
+		"uuid":     "harus berupa UUID yang valid",
+		"date":     "harus berformat YYYY-MM-DD",
+		"ltefield": "tidak boleh setelah '%s'",
+	},
+}
+
+// LocalizedMessage returns the message for f in lang, falling back to English and then to Message.
+func (f FieldError) LocalizedMessage(lang string) string {
+	for _, l := range []string{lang, DefaultLanguage} {
+		format, ok := fieldMessages[l][f.Rule]
+		if !ok {
+			continue
+		}
+		if strings.Contains(format, "%s") {
+			return strings.Replace(format, "%s", f.Param, 1)
+		}
+		return format
+	}
+
+	return f.Message
+}
+
+// SupportsLanguage reports whether field messages are translated into lang.
+func SupportsLanguage(lang string) bool {
+	_, ok := fieldMessages[lang]
+	return ok
+}
@@ -3,41 +3,95 @@ package account
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
-	accountpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/account"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/breaker"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+
+	accountpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/account"
 )
 
 type AccountClient struct {
 	Service accountpb.AccountServiceClient
 	Conn    *grpc.ClientConn
+
+	breaker     *breaker.CircuitBreaker
+	callTimeout time.Duration
+	maxAttempts int
+	backoff     time.Duration
 }
 
-func NewAccountClient(grpcServerAddress string) (*AccountClient, error) {
-	conn, err := grpc.NewClient(grpcServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewAccountClient(cfg *configs.GrpcConfig, creds credentials.TransportCredentials, log *logrus.Logger) (*AccountClient, error) {
+	conn, err := grpc.NewClient(cfg.AccountServiceAddress,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(logctx.UnaryClientInterceptor()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("can't connect to gRPC server: %v", err)
 	}
 
 	serviceClient := accountpb.NewAccountServiceClient(conn)
+	return NewAccountClientFromService(serviceClient, conn, cfg, log), nil
+}
+
+func NewAccountClientFromService(serviceClient accountpb.AccountServiceClient, conn *grpc.ClientConn, cfg *configs.GrpcConfig, log *logrus.Logger) *AccountClient {
+	cb := breaker.New("account", cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout, log)
+	cb.OnStateChange(func(from, to breaker.State) {
+		metrics.AccountCircuitState.Set(float64(to))
+	})
 
 	return &AccountClient{
-		Service: serviceClient,
-		Conn:    conn,
-	}, nil
+		Service:     serviceClient,
+		Conn:        conn,
+		breaker:     cb,
+		callTimeout: cfg.CallTimeout,
+		maxAttempts: max(cfg.MaxAttempts, 1),
+		backoff:     cfg.RetryBackoff,
+	}
 }
 
 func (c *AccountClient) Close() {
 	c.Conn.Close()
 }
 
+// CheckConnection reports the channel state without issuing an RPC. An idle channel is asked to
+// connect and counts as healthy, since the client dials lazily.
+func (c *AccountClient) CheckConnection(ctx context.Context) error {
+	switch state := c.Conn.GetState(); state {
+	case connectivity.Ready:
+		return nil
+	case connectivity.Idle:
+		c.Conn.Connect()
+		return nil
+	default:
+		return fmt.Errorf("account service connection is %s", state)
+	}
+}
+
 // GetUser calls the GetUser RPC with just the ID
 func (c *AccountClient) GetUser(ctx context.Context, id string) (*accountpb.User, error) {
 	req := &accountpb.GetUserRequest{
 		Id: id,
 	}
-	return c.Service.GetUser(ctx, req)
+
+	var res *accountpb.User
+	err := c.call(ctx, "GetUser", func(ctx context.Context) (err error) {
+		res, err = c.Service.GetUser(ctx, req)
+		return err
+	})
+	return res, err
 }
 
 // GetUsers calls the GetUsers RPC with a list of IDs
@@ -45,5 +99,87 @@ func (c *AccountClient) GetUsers(ctx context.Context, ids []string) (*accountpb.
 	req := &accountpb.GetUsersRequest{
 		Ids: ids,
 	}
-	return c.Service.GetUsers(ctx, req)
+
+	var res *accountpb.GetUsersResponse
+	err := c.call(ctx, "GetUsers", func(ctx context.Context) (err error) {
+		res, err = c.Service.GetUsers(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+// ------- HELPERS -------
+
+// call runs fn through the breaker, giving each attempt its own deadline and retrying transient
+// failures with jittered exponential backoff. The breaker sees one outcome per call, not per attempt.
+func (c *AccountClient) call(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	if err := c.breaker.Allow(); err != nil {
+		metrics.AccountCalls.WithLabelValues(method, "rejected").Inc()
+		return status.Error(codes.Unavailable, "account service circuit breaker is open")
+	}
+
+	backoff := c.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, c.callTimeout)
+		err = fn(attemptCtx)
+		cancel()
+
+		if err == nil || !isTransient(err) || attempt >= c.maxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		metrics.AccountCalls.WithLabelValues(method, "retried").Inc()
+		if !sleep(ctx, backoff/2+rand.N(backoff/2+1)) {
+			break
+		}
+		backoff *= 2
+	}
+
+	switch {
+	case ctx.Err() != nil || status.Code(err) == codes.Canceled:
+		// A caller giving up says nothing about the account service; a half-open probe is handed
+		// back so the next call can probe instead.
+		c.breaker.Release()
+		metrics.AccountCalls.WithLabelValues(method, "canceled").Inc()
+		return err
+
+	case err == nil:
+		c.breaker.Success()
+		metrics.AccountCalls.WithLabelValues(method, "ok").Inc()
+		return nil
+
+	case isTransient(err):
+		c.breaker.Failure()
+		metrics.AccountCalls.WithLabelValues(method, "error").Inc()
+		return err
+
+	default:
+		// The service answered, even if the answer is an error.
+		c.breaker.Success()
+		metrics.AccountCalls.WithLabelValues(method, "error").Inc()
+		return err
+	}
+}
+
+// isTransient reports whether a failed call may succeed if retried.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
@@ -0,0 +1,208 @@
+package account
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/breaker"
+
+	accountpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/account"
+)
+
+const (
+	testBackoff     = 20 * time.Millisecond
+	testOpenTimeout = 50 * time.Millisecond
+)
+
+func TestCallRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "unavailable is retried", err: status.Error(codes.Unavailable, "down"), wantAttempts: 3},
+		{name: "deadline exceeded is retried", err: status.Error(codes.DeadlineExceeded, "slow"), wantAttempts: 3},
+		{name: "invalid argument is not retried", err: status.Error(codes.InvalidArgument, "bad id"), wantAttempts: 1},
+		{name: "not found is not retried", err: status.Error(codes.NotFound, "no user"), wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeAccountService{err: tt.err}
+			c := newTestClient(fake, 3, 5)
+
+			_, err := c.GetUser(context.Background(), "u1")
+			if status.Code(err) != status.Code(tt.err) {
+				t.Fatalf("GetUser error = %v, want code %s", err, status.Code(tt.err))
+			}
+			if got := fake.attempts(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestCallBacksOffExponentially(t *testing.T) {
+	fake := &fakeAccountService{err: status.Error(codes.Unavailable, "down")}
+	c := newTestClient(fake, 3, 5)
+
+	if _, err := c.GetUser(context.Background(), "u1"); err == nil {
+		t.Fatal("GetUser succeeded, want an error")
+	}
+
+	calls := fake.callTimes()
+	if len(calls) != 3 {
+		t.Fatalf("attempts = %d, want 3", len(calls))
+	}
+	// Each wait is jittered into [backoff/2, backoff], and backoff doubles after every retry.
+	for i, minWait := range []time.Duration{testBackoff / 2, testBackoff} {
+		if gap := calls[i+1].Sub(calls[i]); gap < minWait {
+			t.Errorf("wait before attempt %d = %s, want at least %s", i+2, gap, minWait)
+		}
+	}
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	fake := &fakeAccountService{err: status.Error(codes.Unavailable, "down")}
+	c := newTestClient(fake, 1, 3)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetUser(context.Background(), "u1"); status.Code(err) != codes.Unavailable {
+			t.Fatalf("call %d error = %v, want Unavailable", i+1, err)
+		}
+	}
+	if got := c.breaker.State(); got != breaker.StateOpen {
+		t.Fatalf("breaker state = %s, want open", got)
+	}
+
+	_, err := c.GetUser(context.Background(), "u1")
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("call while open error = %v, want Unavailable", err)
+	}
+	if got := fake.attempts(); got != 3 {
+		t.Errorf("attempts = %d, want the open breaker to short-circuit the fourth call", got)
+	}
+}
+
+func TestNonTransientErrorsKeepBreakerClosed(t *testing.T) {
+	fake := &fakeAccountService{err: status.Error(codes.InvalidArgument, "bad id")}
+	c := newTestClient(fake, 1, 1)
+
+	for i := 0; i < 3; i++ {
+		c.GetUser(context.Background(), "u1")
+	}
+	if got := c.breaker.State(); got != breaker.StateClosed {
+		t.Errorf("breaker state = %s, want closed", got)
+	}
+}
+
+func TestCanceledCallRecordsNoVerdict(t *testing.T) {
+	t.Run("closed breaker stays closed", func(t *testing.T) {
+		fake := &fakeAccountService{}
+		c := newTestClient(fake, 3, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := c.GetUser(ctx, "u1"); err == nil {
+			t.Fatal("GetUser succeeded on a cancelled context")
+		}
+		if got := fake.attempts(); got != 1 {
+			t.Errorf("attempts = %d, want a cancelled call not to be retried", got)
+		}
+		if got := c.breaker.State(); got != breaker.StateClosed {
+			t.Errorf("breaker state = %s, want closed", got)
+		}
+	})
+
+	t.Run("half-open probe is released", func(t *testing.T) {
+		fake := &fakeAccountService{err: status.Error(codes.Unavailable, "down")}
+		c := newTestClient(fake, 1, 1)
+
+		c.GetUser(context.Background(), "u1")
+		if got := c.breaker.State(); got != breaker.StateOpen {
+			t.Fatalf("breaker state = %s, want open", got)
+		}
+		time.Sleep(testOpenTimeout + 10*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c.GetUser(ctx, "u1")
+		if got := c.breaker.State(); got != breaker.StateHalfOpen {
+			t.Fatalf("breaker state after the cancelled probe = %s, want half-open", got)
+		}
+
+		fake.setErr(nil)
+		if _, err := c.GetUser(context.Background(), "u1"); err != nil {
+			t.Fatalf("GetUser after the cancelled probe = %v, want the next call to probe", err)
+		}
+		if got := c.breaker.State(); got != breaker.StateClosed {
+			t.Errorf("breaker state = %s, want closed after a successful probe", got)
+		}
+	})
+}
+
+// ------- HELPERS -------
+
+func newTestClient(service accountpb.AccountServiceClient, maxAttempts, threshold int) *AccountClient {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	return NewAccountClientFromService(service, nil, &configs.GrpcConfig{
+		CallTimeout:             time.Second,
+		MaxAttempts:             maxAttempts,
+		RetryBackoff:            testBackoff,
+		BreakerFailureThreshold: threshold,
+		BreakerOpenTimeout:      testOpenTimeout,
+	}, log)
+}
+
+// fakeAccountService fails every call with err, or returns a user when err is nil. A cancelled
+// context wins over err, as it would on a real channel.
+type fakeAccountService struct {
+	accountpb.AccountServiceClient
+
+	mu    sync.Mutex
+	err   error
+	calls []time.Time
+}
+
+func (f *fakeAccountService) GetUser(ctx context.Context, in *accountpb.GetUserRequest, opts ...grpc.CallOption) (*accountpb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, time.Now())
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &accountpb.User{Id: in.Id, Name: "Seller"}, nil
+}
+
+func (f *fakeAccountService) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeAccountService) attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func (f *fakeAccountService) callTimes() []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Time(nil), f.calls...)
+}
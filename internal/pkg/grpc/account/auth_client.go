@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+
 	authpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/auth"
 )
 
@@ -19,8 +21,12 @@ type AuthClient struct {
 	conn    *grpc.ClientConn
 }
 
-func NewAuthClient(grpcServerAddress string) (*AuthClient, error) {
-	conn, err := grpc.NewClient(grpcServerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewAuthClient(grpcServerAddress string, creds credentials.TransportCredentials) (*AuthClient, error) {
+	conn, err := grpc.NewClient(grpcServerAddress,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(logctx.UnaryClientInterceptor()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("can't connect to gRPC server: %v", err)
 	}
@@ -39,18 +45,15 @@ func NewAuthClientFromService(serviceClient authpb.AuthServiceClient, conn *grpc
 	}
 }
 
-func (rc *AuthClient) Close() {
+func (rc *AuthClient) Close() error {
 	if rc.conn != nil {
-		log.Printf("Closing gRPC AuthClient connection...")
-		err := rc.conn.Close()
-		if err != nil {
-			log.Printf("Failed to close gRPC connection: %v", err)
-		}
+		return rc.conn.Close()
 	}
+	return nil
 }
 
-func (c *AuthClient) ValidateToken(token string) (isValid bool, userID string, username string, role string, errorMessage string, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (c *AuthClient) ValidateToken(ctx context.Context, token string) (isValid bool, userID string, username string, role string, errorMessage string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	req := &authpb.ValidateTokenRequest{Token: token}
@@ -62,11 +65,7 @@ func (c *AuthClient) ValidateToken(token string) (isValid bool, userID string, u
 				return false, "", "", "", st.Message(), st.Err()
 			}
 		}
-		return false, "", "", "", "Kesalahan internal server", err
-	}
-
-	if !res.IsValid {
-		log.Printf("Token tidak valid: %s", res.GetErrorMessage())
+		return false, "", "", "", "Internal server error", err
 	}
 
 	return res.GetIsValid(), res.GetUserId(), res.GetUsername(), res.GetRole(), res.GetErrorMessage(), nil
@@ -0,0 +1,176 @@
+// Package grpcauth authenticates the services calling the catalog gRPC server and enforces a
+// per-method allowlist of callers. A caller is identified by its verified client certificate
+// when mTLS is configured, otherwise by a pre-shared service token.
+package grpcauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+const anyService = "*"
+
+type serviceKey struct{}
+
+// publicPrefixes are reachable without credentials: probes and, where registered, reflection.
+var publicPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+type Authenticator struct {
+	// tokens maps a SHA-256 of each token to its service, so lookups do not leak timing.
+	tokens    map[[sha256.Size]byte]string
+	allowlist map[string]map[string]struct{}
+	log       *logrus.Logger
+}
+
+func New(cfg *configs.GRPCServerConfig, log *logrus.Logger) (*Authenticator, error) {
+	a := &Authenticator{
+		tokens:    make(map[[sha256.Size]byte]string, len(cfg.ServiceTokens)),
+		allowlist: make(map[string]map[string]struct{}, len(cfg.MethodAllowlist)),
+		log:       log,
+	}
+
+	for _, entry := range cfg.ServiceTokens {
+		service, token, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		service = strings.TrimSpace(service)
+		if !ok || service == "" || token == "" {
+			return nil, fmt.Errorf("grpcauth: invalid service token entry for %q, want service=token", service)
+		}
+		a.tokens[sha256.Sum256([]byte(token))] = service
+	}
+
+	for _, entry := range cfg.MethodAllowlist {
+		method, services, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		method = strings.TrimSpace(method)
+		if !ok || !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("grpcauth: invalid allowlist entry %q, want /package.Service/Method=service,service", entry)
+		}
+
+		allowed := make(map[string]struct{})
+		for _, service := range strings.Split(services, ",") {
+			if service = strings.TrimSpace(service); service != "" {
+				allowed[service] = struct{}{}
+			}
+		}
+		a.allowlist[method] = allowed
+	}
+
+	return a, nil
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// ServiceFromContext returns the caller identified by the interceptors, or "" for public methods.
+func ServiceFromContext(ctx context.Context) string {
+	service, _ := ctx.Value(serviceKey{}).(string)
+	return service
+}
+
+// CertificateIdentity names the service a verified client certificate belongs to.
+func CertificateIdentity(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+// ------- HELPERS -------
+
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	service, ok := a.identify(ctx)
+	if !ok {
+		logctx.From(ctx, a.log).WithField("method", method).Warn("Rejected unauthenticated gRPC call")
+		return nil, apperrors.GRPCStatus(apperrors.ErrUnauthorized.Withf("service credentials required"))
+	}
+
+	allowed := a.allowlist[method]
+	_, named := allowed[service]
+	_, wildcard := allowed[anyService]
+	if !named && !wildcard {
+		logctx.From(ctx, a.log).WithFields(logrus.Fields{"method": method, "caller": service}).Warn("Rejected gRPC call not on the method allowlist")
+		return nil, apperrors.GRPCStatus(apperrors.ErrAccessDenied.Withf("%s may not call %s", service, method))
+	}
+
+	return context.WithValue(ctx, serviceKey{}, service), nil
+}
+
+// identify prefers a verified client certificate over a token.
+func (a *Authenticator) identify(ctx context.Context) (string, bool) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			if name := CertificateIdentity(info.State.VerifiedChains[0][0]); name != "" {
+				return name, true
+			}
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if !ok {
+			continue
+		}
+
+		sum := sha256.Sum256([]byte(token))
+		for known, service := range a.tokens {
+			if subtle.ConstantTimeCompare(sum[:], known[:]) == 1 {
+				return service, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// serverStream carries the authenticated context to stream handlers.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,143 @@
+package grpcauth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+)
+
+const (
+	getProducts   = "/product.ProductService/GetProducts"
+	decreaseStock = "/product.ProductService/DecreaseStock"
+	unlisted      = "/product.ProductService/DeleteProduct"
+)
+
+func TestAuthorize(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	a, err := New(&configs.GRPCServerConfig{
+		ServiceTokens:   []string{"orders=orders-token", "search=search-token"},
+		MethodAllowlist: []string{getProducts + "=*", decreaseStock + "=orders"},
+	}, log)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		ctx         context.Context
+		wantCode    codes.Code
+		wantService string
+	}{
+		{name: "no credentials", method: getProducts, ctx: context.Background(), wantCode: codes.Unauthenticated},
+		{name: "unknown token", method: getProducts, ctx: withToken("Bearer forged-token"), wantCode: codes.Unauthenticated},
+		{name: "token without the Bearer scheme", method: getProducts, ctx: withToken("orders-token"), wantCode: codes.Unauthenticated},
+		{name: "allowlisted service", method: decreaseStock, ctx: withToken("Bearer orders-token"), wantCode: codes.OK, wantService: "orders"},
+		{name: "service not on the allowlist", method: decreaseStock, ctx: withToken("Bearer search-token"), wantCode: codes.PermissionDenied},
+		{name: "wildcard admits any authenticated service", method: getProducts, ctx: withToken("Bearer search-token"), wantCode: codes.OK, wantService: "search"},
+		{name: "unlisted method", method: unlisted, ctx: withToken("Bearer orders-token"), wantCode: codes.PermissionDenied},
+		{name: "health check is public", method: "/grpc.health.v1.Health/Check", ctx: context.Background(), wantCode: codes.OK},
+		{name: "reflection is public", method: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", ctx: context.Background(), wantCode: codes.OK},
+		{
+			name:        "verified certificate wins over metadata",
+			method:      decreaseStock,
+			ctx:         withCert(withToken("Bearer search-token"), &x509.Certificate{Subject: pkix.Name{CommonName: "orders"}}, true),
+			wantCode:    codes.OK,
+			wantService: "orders",
+		},
+		{
+			name:     "verified certificate is not upgraded by metadata",
+			method:   decreaseStock,
+			ctx:      withCert(withToken("Bearer orders-token"), &x509.Certificate{Subject: pkix.Name{CommonName: "search"}}, true),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:        "certificate without a common name uses its DNS name",
+			method:      decreaseStock,
+			ctx:         withCert(context.Background(), &x509.Certificate{DNSNames: []string{"orders"}}, true),
+			wantCode:    codes.OK,
+			wantService: "orders",
+		},
+		{
+			name:        "unverified certificate falls back to the token",
+			method:      getProducts,
+			ctx:         withCert(withToken("Bearer search-token"), &x509.Certificate{Subject: pkix.Name{CommonName: "orders"}}, false),
+			wantCode:    codes.OK,
+			wantService: "search",
+		},
+		{
+			name:     "unverified certificate alone is not enough",
+			method:   getProducts,
+			ctx:      withCert(context.Background(), &x509.Certificate{Subject: pkix.Name{CommonName: "orders"}}, false),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := a.authorize(tt.ctx, tt.method)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("authorize code = %s, want %s (err %v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := ServiceFromContext(ctx); got != tt.wantService {
+				t.Errorf("ServiceFromContext = %q, want %q", got, tt.wantService)
+			}
+		})
+	}
+}
+
+func TestNewRejectsMalformedEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  configs.GRPCServerConfig
+	}{
+		{name: "token without service", cfg: configs.GRPCServerConfig{ServiceTokens: []string{"=secret"}}},
+		{name: "service without token", cfg: configs.GRPCServerConfig{ServiceTokens: []string{"orders="}}},
+		{name: "allowlist without separator", cfg: configs.GRPCServerConfig{MethodAllowlist: []string{getProducts}}},
+		{name: "allowlist method without leading slash", cfg: configs.GRPCServerConfig{MethodAllowlist: []string{"product.ProductService/GetProducts=*"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(&tt.cfg, logrus.New()); err == nil {
+				t.Error("New accepted a malformed entry")
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func withToken(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func withCert(ctx context.Context, cert *x509.Certificate, verified bool) context.Context {
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50051},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
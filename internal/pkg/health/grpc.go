@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// SyncGRPC mirrors readiness into the standard grpc.health.v1 service for the overall server
+// ("") and each named service. It blocks until ctx is cancelled, then reports NOT_SERVING.
+func (c *Checker) SyncGRPC(ctx context.Context, server *health.Server, interval time.Duration, services ...string) {
+	services = append([]string{""}, services...)
+
+	set := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		for _, svc := range services {
+			server.SetServingStatus(svc, status)
+		}
+	}
+
+	update := func() {
+		if c.Check(ctx).Ready() {
+			set(healthpb.HealthCheckResponse_SERVING)
+			return
+		}
+		set(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			set(healthpb.HealthCheckResponse_NOT_SERVING)
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Classification says what a failing dependency means for the service: a critical failure
+// makes it unready, a degraded one only reduces functionality.
+type Classification string
+
+const (
+	Critical Classification = "critical"
+	Degraded Classification = "degraded"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name           string
+	classification Classification
+	fn             CheckFunc
+}
+
+type Result struct {
+	Name           string
+	Status         Status
+	Classification Classification
+	Latency        time.Duration
+	Error          string
+}
+
+type Report struct {
+	Status    Status
+	CheckedAt time.Time
+	Checks    []Result
+}
+
+// Ready reports whether the service should receive traffic.
+func (r *Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Checker runs the registered dependency checks concurrently, each under its own timeout.
+type Checker struct {
+	timeout      time.Duration
+	checks       []check
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+func (c *Checker) Register(name string, classification Classification, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, classification: classification, fn: fn})
+}
+
+// MarkShuttingDown makes every later report unready so load balancers drain the instance.
+func (c *Checker) MarkShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) Check(ctx context.Context) *Report {
+	if c.shuttingDown.Load() {
+		return &Report{Status: StatusDown, CheckedAt: time.Now()}
+	}
+
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	report := &Report{Status: StatusUp, CheckedAt: time.Now(), Checks: results}
+	for _, res := range results {
+		if res.Status == StatusUp {
+			continue
+		}
+		if res.Classification == Critical {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, chk check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := chk.fn(ctx)
+
+	res := Result{
+		Name:           chk.name,
+		Status:         StatusUp,
+		Classification: chk.classification,
+		Latency:        time.Since(start),
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+
+	return res
+}
@@ -0,0 +1,31 @@
+package logctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+)
+
+// InjectAMQP copies the request ID of ctx into message headers, allocating them if needed.
+func InjectAMQP(ctx context.Context, headers amqp.Table) amqp.Table {
+	if headers == nil {
+		headers = amqp.Table{}
+	}
+
+	if id := FieldsFrom(ctx).RequestID; id != "" {
+		headers[RequestIDKey] = id
+	}
+	return headers
+}
+
+// ExtractAMQP returns ctx carrying the request ID found in headers, generating one for
+// messages from producers that do not send it.
+func ExtractAMQP(ctx context.Context, headers amqp.Table) context.Context {
+	id, _ := headers[RequestIDKey].(string)
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	return WithRequestID(ctx, id)
+}
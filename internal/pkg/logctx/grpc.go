@@ -0,0 +1,81 @@
+package logctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor restores the request fields sent by the caller,
+// generating a request ID when the caller did not provide one.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(incoming(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: incoming(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor forwards the request fields in ctx to the callee.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func incoming(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f := Fields{
+		RequestID: first(md, RequestIDKey),
+		UserID:    first(md, UserIDKey),
+		Role:      first(md, RoleKey),
+	}
+	if f.RequestID == "" {
+		f.RequestID = uuid.NewString()
+	}
+
+	return WithFields(ctx, f)
+}
+
+func outgoing(ctx context.Context) context.Context {
+	f := FieldsFrom(ctx)
+
+	pairs := make([]string, 0, 6)
+	if f.RequestID != "" {
+		pairs = append(pairs, RequestIDKey, f.RequestID)
+	}
+	if f.UserID != "" {
+		pairs = append(pairs, UserIDKey, f.UserID)
+	}
+	if f.Role != "" {
+		pairs = append(pairs, RoleKey, f.Role)
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,83 @@
+// Package logctx carries request-scoped log fields (request ID, caller
+// identity) through a context so every log line of a request can be
+// correlated, across HTTP, gRPC and the services in between.
+package logctx
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Metadata keys used to propagate the fields between services over gRPC.
+const (
+	RequestIDKey = "x-request-id"
+	UserIDKey    = "x-user-id"
+	RoleKey      = "x-user-role"
+)
+
+type fieldsKey struct{}
+
+// Fields are the request-scoped values attached to every log line.
+type Fields struct {
+	RequestID string
+	UserID    string
+	Role      string
+}
+
+// FieldsFrom returns the fields stored in ctx, or the zero value.
+func FieldsFrom(ctx context.Context) Fields {
+	f, _ := ctx.Value(fieldsKey{}).(Fields)
+	return f
+}
+
+// WithFields stores f in ctx, replacing any fields already present.
+func WithFields(ctx context.Context, f Fields) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// WithRequestID returns a copy of ctx carrying the given request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	f := FieldsFrom(ctx)
+	f.RequestID = requestID
+	return WithFields(ctx, f)
+}
+
+// WithUser returns a copy of ctx carrying the authenticated caller.
+func WithUser(ctx context.Context, userID, role string) context.Context {
+	f := FieldsFrom(ctx)
+	f.UserID = userID
+	f.Role = role
+	return WithFields(ctx, f)
+}
+
+// From returns a log entry enriched with the request fields and the
+// active trace ID from ctx. Empty values are omitted.
+func From(ctx context.Context, log *logrus.Logger) *logrus.Entry {
+	entry := logrus.NewEntry(log).WithContext(ctx)
+	if ctx == nil {
+		return entry
+	}
+
+	fields := logrus.Fields{}
+	f := FieldsFrom(ctx)
+	if f.RequestID != "" {
+		fields["request_id"] = f.RequestID
+	}
+	if f.UserID != "" {
+		fields["user_id"] = f.UserID
+	}
+	if f.Role != "" {
+		fields["role"] = f.Role
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields["trace_id"] = sc.TraceID().String()
+	}
+
+	if len(fields) == 0 {
+		return entry
+	}
+
+	return entry.WithFields(fields)
+}
@@ -1,26 +1,37 @@
 package messaging
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"sync"
 
+	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/tracing"
 )
 
 type Manager struct {
 	channel      *amqp.Channel
-	inputChan    chan NotificationPayload
+	inputChan    chan envelope
 	exchangeName string
+	log          *logrus.Logger
+
+	// mu guards closed so Send never writes to a closed inputChan during shutdown.
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
 }
 
-func NewManager(amqpConn *amqp.Connection, bufferSize int) (*Manager, error) {
+func NewManager(amqpConn *amqp.Connection, bufferSize int, log *logrus.Logger) (*Manager, error) {
 	ch, err := amqpConn.Channel()
 	if err != nil {
 		return nil, err
 	}
 
-	// Declare Exchange agar aman (idempotent)
-	// Gunakan nama exchange unik global, misal "tokohobby.events"
+	// Declaring is idempotent; the exchange name is shared by every tokohobby service.
 	err = ch.ExchangeDeclare("tokohobby.events", "topic", true, false, false, false, nil)
 	if err != nil {
 		return nil, err
@@ -28,54 +39,114 @@ func NewManager(amqpConn *amqp.Connection, bufferSize int) (*Manager, error) {
 
 	mgr := &Manager{
 		channel:      ch,
-		inputChan:    make(chan NotificationPayload, bufferSize),
+		inputChan:    make(chan envelope, bufferSize),
 		exchangeName: "tokohobby.events",
+		log:          log,
+		done:         make(chan struct{}),
 	}
 
-	// Jalankan worker background
 	go mgr.worker()
 
 	return mgr, nil
 }
 
-// worker memindahkan data dari memory (Channel) ke RabbitMQ
+// worker drains the in-memory buffer into RabbitMQ.
 func (m *Manager) worker() {
-	for payload := range m.inputChan {
-		body, err := json.Marshal(payload)
-		if err != nil {
-			log.Printf("Messaging Marshal Error: %v", err)
-			continue
-		}
-
-		err = m.channel.Publish(
-			m.exchangeName,
-			string(payload.Type), // Routing Key
-			false,
-			false,
-			amqp.Publishing{
-				ContentType: "application/json",
-				Body:        body,
-			},
-		)
-
-		if err != nil {
-			log.Printf("RabbitMQ Publish Error: %v", err)
-		}
+	defer close(m.done)
+
+	for env := range m.inputChan {
+		m.publish(env)
+	}
+}
+
+func (m *Manager) publish(env envelope) {
+	payload := env.payload
+	routingKey := string(payload.Type)
+
+	// The caller's request is long gone; only its trace context travels with the event.
+	ctx := tracing.ExtractAMQP(context.Background(), env.headers)
+	ctx = logctx.ExtractAMQP(ctx, env.headers)
+	_, span, headers := tracing.StartPublish(ctx, m.exchangeName, routingKey, nil)
+	headers = logctx.InjectAMQP(ctx, headers)
+	logger := logctx.From(ctx, m.log).WithField("event_type", payload.Type)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.WithError(err).Error("Event not published, marshal failed")
+		tracing.EndSpan(span, err)
+		return
 	}
+
+	err = m.channel.Publish(
+		m.exchangeName,
+		routingKey,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Headers:     headers,
+			Body:        body,
+		},
+	)
+
+	if err != nil {
+		metrics.MessagingPublishErrors.Inc()
+		logger.WithError(err).Error("Event not published")
+	} else {
+		logger.Debug("Event published")
+	}
+	tracing.EndSpan(span, err)
 }
 
-// Send (Public Method) - Non blocking!
-func (m *Manager) Send(payload NotificationPayload) {
+// envelope carries the sender's trace context and request ID across the buffer to the worker.
+type envelope struct {
+	payload NotificationPayload
+	headers amqp.Table
+}
+
+// Send buffers payload for publishing without blocking the caller.
+func (m *Manager) Send(ctx context.Context, payload NotificationPayload) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		metrics.MessagingDropped.WithLabelValues(metrics.DropShuttingDown).Inc()
+		logctx.From(ctx, m.log).WithField("event_type", payload.Type).Warn("Event dropped, messaging is shutting down")
+		return
+	}
+
 	select {
-	case m.inputChan <- payload:
-		// Masuk antrian memory
+	case m.inputChan <- envelope{payload: payload, headers: logctx.InjectAMQP(ctx, tracing.InjectAMQP(ctx, nil))}:
 	default:
-		// Antrian penuh, drop biar ga bikin lemot user request
-		log.Println("Messaging Buffer Full! Dropped event:", payload.Type)
+		// Dropping beats blocking the user's request on a slow broker.
+		metrics.MessagingDropped.WithLabelValues(metrics.DropBufferFull).Inc()
+		logctx.From(ctx, m.log).WithField("event_type", payload.Type).Warn("Event dropped, messaging buffer is full")
 	}
 }
 
-func (m *Manager) Close() {
-	close(m.inputChan)
+// Shutdown stops accepting events and waits for the worker to publish everything already
+// buffered, or for ctx to expire, before closing the channel.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.inputChan)
+	}
+	m.mu.Unlock()
+
+	var err error
+	select {
+	case <-m.done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		metrics.MessagingDropped.WithLabelValues(metrics.DropFlushTimeout).Add(float64(len(m.inputChan)))
+		m.log.WithField("dropped", len(m.inputChan)).Warn("Messaging shutdown timed out, buffered events dropped")
+	}
+
 	m.channel.Close()
+	return err
+}
+
+func (m *Manager) Close() {
+	_ = m.Shutdown(context.Background())
 }
@@ -4,6 +4,11 @@ import "github.com/google/uuid"
 
 type NotificationType string
 
+const (
+	ProductStatusChanged NotificationType = "product.status_changed"
+	QuestionAnswered     NotificationType = "product.question_answered"
+)
+
 type NotificationPayload struct {
 	Type    NotificationType `json:"type"`
 	UserID  uuid.UUID        `json:"user_id"`
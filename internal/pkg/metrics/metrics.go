@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "catalog"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	GRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests by full method and status code.",
+	}, []string{"method", "code"})
+
+	GRPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by full method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// CacheLookups is labelled by cache namespace (key family). result is one of l1_hit, hit,
+	// miss or bypass; hit ratio = (l1_hit + hit) / total.
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Cache lookups by namespace and result.",
+	}, []string{"cache", "result"})
+
+	StockDecrementFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_decrement_failures_total",
+		Help:      "Failed DecreaseStock calls by reason.",
+	}, []string{"reason"})
+
+	MessagingDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messaging_events_dropped_total",
+		Help:      "Outgoing events dropped before reaching RabbitMQ, by reason.",
+	}, []string{"reason"})
+
+	MessagingPublishErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messaging_publish_errors_total",
+		Help:      "Outgoing events RabbitMQ refused to accept.",
+	})
+
+	RedisCircuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "redis_circuit_state",
+		Help:      "Redis circuit breaker state: 0 closed, 1 open, 2 half-open.",
+	})
+
+	RedisCircuitTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_circuit_transitions_total",
+		Help:      "Redis circuit breaker transitions by target state.",
+	}, []string{"to"})
+
+	RedisCircuitRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_circuit_rejected_total",
+		Help:      "Redis commands rejected while the circuit was open.",
+	})
+
+	AccountCircuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "account_circuit_state",
+		Help:      "Account service circuit breaker state: 0 closed, 1 open, 2 half-open.",
+	})
+
+	AccountCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "account_calls_total",
+		Help:      "Account service calls by method and outcome (ok, error, canceled, retried, rejected).",
+	}, []string{"method", "result"})
+
+	AuthVerifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_verifications_total",
+		Help:      "Bearer token verifications by method (local, remote, revocation) and result.",
+	}, []string{"method", "result"})
+
+	RateLimitDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_decisions_total",
+		Help:      "Rate limit decisions by route group and result.",
+	}, []string{"group", "result"})
+)
+
+// Cache lookup results.
+const (
+	CacheL1Hit  = "l1_hit"
+	CacheHit    = "hit"
+	CacheMiss   = "miss"
+	CacheBypass = "bypass"
+)
+
+// Stock decrement failure reasons.
+const (
+	StockOutOfStock = "out_of_stock"
+	StockInternal   = "internal"
+)
+
+// Auth verification results.
+const (
+	AuthValid       = "valid"
+	AuthInvalid     = "invalid"
+	AuthRevoked     = "revoked"
+	AuthUnavailable = "unavailable"
+)
+
+// Rate limit decision results.
+const (
+	RateLimitAllowed = "allowed"
+	RateLimitLimited = "limited"
+	RateLimitExempt  = "exempt"
+	RateLimitError   = "error"
+)
+
+// Messaging drop reasons.
+const (
+	DropBufferFull   = "buffer_full"
+	DropShuttingDown = "shutting_down"
+	DropFlushTimeout = "flush_timeout"
+)
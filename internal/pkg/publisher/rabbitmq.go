@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/tracing"
+	"github.com/sirupsen/logrus"
 	"github.com/streadway/amqp"
 )
 
@@ -16,9 +18,10 @@ const (
 
 type RabbitMQPublisher struct {
 	channel *amqp.Channel
+	log     *logrus.Logger
 }
 
-func NewRabbitMQPublisher(ch *amqp.Channel) (*RabbitMQPublisher, error) {
+func NewRabbitMQPublisher(ch *amqp.Channel, log *logrus.Logger) (*RabbitMQPublisher, error) {
 	err := ch.ExchangeDeclare(
 		OrderExchange, // name
 		"fanout",      // type
@@ -29,10 +32,10 @@ func NewRabbitMQPublisher(ch *amqp.Channel) (*RabbitMQPublisher, error) {
 		nil,           // arguments
 	)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mendeklarasikan exchange: %w", err)
+		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	return &RabbitMQPublisher{channel: ch}, nil
+	return &RabbitMQPublisher{channel: ch, log: log}, nil
 }
 
 func (p *RabbitMQPublisher) PublishOrderCreated(ctx context.Context, event models.OrderCreatedEvent) error {
@@ -48,6 +51,7 @@ func (p *RabbitMQPublisher) PublishOrderCreated(ctx context.Context, event model
 		false,         // immediate
 		amqp.Publishing{
 			ContentType: "application/json",
+			Headers:     logctx.InjectAMQP(ctx, tracing.InjectAMQP(ctx, nil)),
 			Body:        body,
 		})
 
@@ -55,6 +59,6 @@ func (p *RabbitMQPublisher) PublishOrderCreated(ctx context.Context, event model
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	log.Printf("OrderCreated event was successfully published for Order ID: %s", event.OrderID)
+	logctx.From(ctx, p.log).WithField("order_id", event.OrderID).Info("Order created event published")
 	return nil
 }
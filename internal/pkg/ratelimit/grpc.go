@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpcauth"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+)
+
+// UnaryServerInterceptor limits gRPC calls under group. It must run after the grpcauth
+// interceptors, which identify the calling service.
+func (l *Limiter) UnaryServerInterceptor(group string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allowGRPC(ctx, group); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (l *Limiter) StreamServerInterceptor(group string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allowGRPC(ss.Context(), group); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// ------- HELPERS -------
+
+func (l *Limiter) allowGRPC(ctx context.Context, group string) error {
+	service := grpcauth.ServiceFromContext(ctx)
+	identity := "service:" + service
+	if service == "" {
+		// Only public methods such as health checks get here unauthenticated.
+		identity = "ip:" + peerIP(ctx)
+	} else if l.Exempt(service) {
+		metrics.RateLimitDecisions.WithLabelValues(group, metrics.RateLimitExempt).Inc()
+		return nil
+	}
+
+	if decision := l.Allow(ctx, group, identity); !decision.Allowed {
+		return apperrors.GRPCStatus(apperrors.ErrRateLimited.Withf("rate limit of %d calls per %s exceeded", decision.Limit, decision.Window))
+	}
+
+	return nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
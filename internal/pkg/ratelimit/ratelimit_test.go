@@ -0,0 +1,277 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpcauth"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+// windowStart is aligned to a minute, so offsets from it land at known points in a 1m window.
+var windowStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		entry     string
+		wantGroup string
+		wantRule  Rule
+		wantErr   bool
+	}{
+		{entry: "search=30/1m", wantGroup: "search", wantRule: Rule{Limit: 30, Window: time.Minute}},
+		{entry: " cart = 60 / 30s ", wantGroup: "cart", wantRule: Rule{Limit: 60, Window: 30 * time.Second}},
+		{entry: "public=1/1s", wantGroup: "public", wantRule: Rule{Limit: 1, Window: time.Second}},
+		{entry: "search", wantErr: true},
+		{entry: "search=30", wantErr: true},
+		{entry: "=30/1m", wantErr: true},
+		{entry: "search=0/1m", wantErr: true},
+		{entry: "search=-5/1m", wantErr: true},
+		{entry: "search=many/1m", wantErr: true},
+		{entry: "search=30/minute", wantErr: true},
+		{entry: "search=30/500ms", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			group, rule, err := parseRule(tt.entry)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseRule(%q) = %q, %+v; want an error", tt.entry, group, rule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRule(%q): %v", tt.entry, err)
+			}
+			if group != tt.wantGroup || rule != tt.wantRule {
+				t.Errorf("parseRule(%q) = %q, %+v; want %q, %+v", tt.entry, group, rule, tt.wantGroup, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidRules(t *testing.T) {
+	cfg := &configs.RateLimitConfig{Enabled: true, Rules: []string{"search=30/1m", "cart=lots/1m"}}
+	if _, err := New(cfg, nil, discardLogger()); err == nil {
+		t.Error("New accepted an invalid rule")
+	}
+
+	// A disabled limiter never reads its rules.
+	cfg.Enabled = false
+	if _, err := New(cfg, nil, discardLogger()); err != nil {
+		t.Errorf("New with limiting disabled: %v", err)
+	}
+}
+
+func TestLimitHoldsAcrossWindowBoundary(t *testing.T) {
+	l, _ := newTestLimiter(t, configs.RateLimitConfig{Rules: []string{"search=10/1m"}})
+	ctx := context.Background()
+
+	steps := []struct {
+		at          time.Duration
+		wantAllowed int
+	}{
+		// Late in the first window the full budget is available.
+		{at: 50 * time.Second, wantAllowed: 10},
+		// A quarter into the next window, three quarters of the previous count still weigh in.
+		{at: 75 * time.Second, wantAllowed: 3},
+		// Three quarters in, only a quarter of it does: floor(10*0.25) + 3 = 5 used.
+		{at: 105 * time.Second, wantAllowed: 5},
+	}
+
+	for _, step := range steps {
+		l.now = func() time.Time { return windowStart.Add(step.at) }
+
+		allowed := 0
+		for i := 0; i < 20; i++ {
+			if l.Allow(ctx, "search", "user:u1").Allowed {
+				allowed++
+			}
+		}
+		if allowed != step.wantAllowed {
+			t.Errorf("at %s: %d requests allowed, want %d", step.at, allowed, step.wantAllowed)
+		}
+	}
+}
+
+func TestRejectedRequestsDoNotConsumeBudget(t *testing.T) {
+	l, mr := newTestLimiter(t, configs.RateLimitConfig{Rules: []string{"search=3/1m"}})
+	l.now = func() time.Time { return windowStart.Add(10 * time.Second) }
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		l.Allow(ctx, "search", "user:u1")
+	}
+
+	index := windowStart.UnixMilli() / time.Minute.Milliseconds()
+	if got, _ := mr.Get(l.key("search", "user:u1", index)); got != "3" {
+		t.Errorf("window counter = %q, want 3", got)
+	}
+
+	// Two thirds into the next window the previous count weighs floor(3/3) = 1.
+	l.now = func() time.Time { return windowStart.Add(100 * time.Second) }
+	d := l.Allow(ctx, "search", "user:u1")
+	if !d.Allowed || d.Remaining != 1 {
+		t.Errorf("Allow in the next window = %+v, want allowed with 1 remaining", d)
+	}
+}
+
+func TestIdentitiesAndGroupsAreCountedSeparately(t *testing.T) {
+	l, _ := newTestLimiter(t, configs.RateLimitConfig{Rules: []string{"search=1/1m", "cart=1/1m"}})
+	ctx := context.Background()
+
+	for _, call := range []struct{ group, identity string }{
+		{"search", "user:u1"},
+		{"search", "user:u2"},
+		{"search", "ip:10.0.0.1"},
+		{"cart", "user:u1"},
+	} {
+		if d := l.Allow(ctx, call.group, call.identity); !d.Allowed {
+			t.Errorf("Allow(%s, %s) = %+v, want allowed", call.group, call.identity, d)
+		}
+	}
+
+	if d := l.Allow(ctx, "search", "user:u1"); d.Allowed {
+		t.Error("second search by user:u1 allowed, want limited")
+	}
+	if d := l.Allow(ctx, "unlisted", "user:u1"); !d.Allowed || d.Limit != 0 {
+		t.Errorf("Allow for a group without a rule = %+v, want allowed and unlimited", d)
+	}
+}
+
+func TestRedisErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		failClosed  bool
+		wantAllowed bool
+	}{
+		{name: "fail open", failClosed: false, wantAllowed: true},
+		{name: "fail closed", failClosed: true, wantAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, mr := newTestLimiter(t, configs.RateLimitConfig{Rules: []string{"search=10/1m"}, FailClosed: tt.failClosed})
+			mr.Close()
+
+			d := l.Allow(context.Background(), "search", "user:u1")
+			if d.Allowed != tt.wantAllowed {
+				t.Errorf("Allowed = %t, want %t", d.Allowed, tt.wantAllowed)
+			}
+			if d.Limit != 10 || d.Remaining != 10 {
+				t.Errorf("Limit, Remaining = %d, %d; want the rule reported with its full budget", d.Limit, d.Remaining)
+			}
+		})
+	}
+}
+
+func TestGRPCIdentityAndExemption(t *testing.T) {
+	const method = "/product.ProductService/GetProducts"
+
+	auth, err := grpcauth.New(&configs.GRPCServerConfig{
+		ServiceTokens:   []string{"orders=orders-token", "search=search-token"},
+		MethodAllowlist: []string{method + "=*"},
+	}, discardLogger())
+	if err != nil {
+		t.Fatalf("grpcauth.New: %v", err)
+	}
+
+	l, mr := newTestLimiter(t, configs.RateLimitConfig{Rules: []string{"grpc=1/1m"}, ExemptServices: []string{"orders"}})
+	l.now = func() time.Time { return windowStart.Add(10 * time.Second) }
+	index := windowStart.UnixMilli() / time.Minute.Milliseconds()
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+		// wantKey is the identity counted, unless the caller is exempt.
+		wantKey string
+	}{
+		{name: "exempt service", method: method, token: "orders-token", wantCode: codes.OK, wantKey: "service:orders"},
+		{name: "limited service", method: method, token: "search-token", wantCode: codes.ResourceExhausted, wantKey: "service:search"},
+		{name: "public method by peer IP", method: "/grpc.health.v1.Health/Check", wantCode: codes.ResourceExhausted, wantKey: "ip:unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+
+			var err error
+			for i := 0; i < 3; i++ {
+				err = callThrough(ctx, tt.method, auth, l)
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("third call = %v, want %s", err, tt.wantCode)
+			}
+
+			counted := mr.Exists(l.key("grpc", tt.wantKey, index))
+			if exempt := tt.wantCode == codes.OK; counted == exempt {
+				t.Errorf("counter for %s exists = %t, want %t; keys: %v", tt.wantKey, counted, !exempt, mr.Keys())
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func discardLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+func newTestLimiter(t *testing.T, cfg configs.RateLimitConfig) (*Limiter, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	port, _ := strconv.Atoi(mr.Port())
+
+	client, err := customRedis.NewRedisClient(&configs.RedisConfig{
+		Host:                    mr.Host(),
+		Port:                    port,
+		DialTimeout:             time.Second,
+		OperationTimeout:        time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, discardLogger())
+	if err != nil {
+		t.Fatalf("NewRedisClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	cfg.Enabled = true
+	cfg.KeyPrefix = "ratelimit:"
+	l, err := New(&cfg, client, discardLogger())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return l, mr
+}
+
+// callThrough runs one unary call through the authentication and rate limit interceptors, in the
+// order the server chains them.
+func callThrough(ctx context.Context, method string, auth *grpcauth.Authenticator, l *Limiter) error {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	limit := l.UnaryServerInterceptor("grpc")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	_, err := auth.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return limit(ctx, req, info, handler)
+	})
+	return err
+}
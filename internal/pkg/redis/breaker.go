@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/breaker"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
+)
+
+// ErrCircuitOpen is returned instead of dialing Redis while the breaker considers it unavailable.
+var ErrCircuitOpen = errors.New("redis: circuit breaker is open")
+
+type State = breaker.State
+
+const (
+	StateClosed   = breaker.StateClosed
+	StateOpen     = breaker.StateOpen
+	StateHalfOpen = breaker.StateHalfOpen
+)
+
+// newBreaker wires the Redis breaker to its metrics.
+func newBreaker(threshold int, openTimeout time.Duration, log *logrus.Logger) *breaker.CircuitBreaker {
+	b := breaker.New("redis", threshold, openTimeout, log)
+	b.OnStateChange(func(from, to State) {
+		metrics.RedisCircuitState.Set(float64(to))
+		metrics.RedisCircuitTransitions.WithLabelValues(to.String()).Inc()
+	})
+	return b
+}
+
+// breakerHook plugs the breaker into every command and pipeline issued through the client.
+type breakerHook struct {
+	breaker *breaker.CircuitBreaker
+}
+
+func (h breakerHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return ctx, h.allow()
+}
+
+func (h breakerHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.record(cmd.Err())
+	return nil
+}
+
+func (h breakerHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, h.allow()
+}
+
+func (h breakerHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if err = cmd.Err(); isConnectionError(err) {
+			break
+		}
+	}
+
+	h.record(err)
+	return nil
+}
+
+func (h breakerHook) allow() error {
+	if err := h.breaker.Allow(); err != nil {
+		metrics.RedisCircuitRejected.Inc()
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+func (h breakerHook) record(err error) {
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return
+	case errors.Is(err, context.Canceled):
+		// The caller gave up before Redis answered, which is neither a success nor a failure.
+		h.breaker.Release()
+	case isConnectionError(err):
+		h.breaker.Failure()
+	default:
+		h.breaker.Success()
+	}
+}
+
+// isConnectionError separates "Redis is unreachable" from misses, server replies and callers
+// giving up, none of which say anything about Redis health.
+func isConnectionError(err error) bool {
+	if err == nil || err == redis.Nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var replyErr redis.Error
+	return !errors.As(err, &replyErr)
+}
+
+// IsUnavailable reports whether err means Redis could not be reached, as opposed to a miss or
+// a server-side reply error.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || isConnectionError(err)
+}
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/breaker"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "miss", err: redis.Nil, want: false},
+		{name: "server reply", err: replyError("WRONGTYPE Operation against a key holding the wrong kind of value"), want: false},
+		{name: "caller cancelled", err: context.Canceled, want: false},
+		{name: "wrapped cancellation", err: fmt.Errorf("get: %w", context.Canceled), want: false},
+		{name: "circuit open", err: ErrCircuitOpen, want: false},
+		{name: "dial failure", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "timeout", err: context.DeadlineExceeded, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConnectionError(tt.err); got != tt.want {
+				t.Errorf("isConnectionError(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerHookRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want breaker.State
+	}{
+		{name: "success closes", err: nil, want: StateClosed},
+		{name: "miss closes", err: redis.Nil, want: StateClosed},
+		{name: "connection error re-opens", err: &net.OpError{Op: "read", Err: errors.New("connection reset")}, want: StateOpen},
+		{name: "cancellation leaves it half-open", err: context.Canceled, want: StateHalfOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := breakerHook{breaker: halfOpenBreaker(t)}
+
+			if err := h.allow(); err != nil {
+				t.Fatalf("allow = %v, want the probe", err)
+			}
+			h.record(tt.err)
+
+			if got := h.breaker.State(); got != tt.want {
+				t.Errorf("state = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerHookCancellationReleasesProbe(t *testing.T) {
+	h := breakerHook{breaker: halfOpenBreaker(t)}
+
+	if err := h.allow(); err != nil {
+		t.Fatalf("allow = %v, want the probe", err)
+	}
+	if err := h.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second allow = %v, want ErrCircuitOpen while the probe is out", err)
+	}
+
+	h.record(context.Canceled)
+
+	if err := h.allow(); err != nil {
+		t.Errorf("allow after a cancelled probe = %v, want a new probe", err)
+	}
+}
+
+// ------- HELPERS -------
+
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+func (e replyError) RedisError() {}
+
+func halfOpenBreaker(t *testing.T) *breaker.CircuitBreaker {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	b := breaker.New("redis", 1, time.Millisecond, log)
+	b.Trip()
+	time.Sleep(5 * time.Millisecond)
+	return b
+}
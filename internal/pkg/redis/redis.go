@@ -1,47 +1,72 @@
 package redis
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"context"
+	"net"
+	"strconv"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/breaker"
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 )
 
 type RedisClient struct {
-	Client *redis.Client
-	log    *logrus.Logger
+	Client  *redis.Client
+	breaker *breaker.CircuitBreaker
+	log     *logrus.Logger
 }
 
+// NewRedisClient connects and pings Redis. An unreachable Redis does not fail startup: the
+// circuit breaker starts open and callers degrade until a probe succeeds.
 func NewRedisClient(cfg *configs.RedisConfig, log *logrus.Logger) (*RedisClient, error) {
-	redisHost := os.Getenv("REDIS_HOST")
-	if redisHost == "" {
-		redisHost = "localhost"
-	}
+	redisAddr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr:         redisAddr,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.OperationTimeout,
+		WriteTimeout: cfg.OperationTimeout,
+	})
+
+	cb := newBreaker(cfg.BreakerFailureThreshold, cfg.BreakerOpenTimeout, log)
+	rdb.AddHook(tracingHook{})
+	rdb.AddHook(breakerHook{breaker: cb})
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DialTimeout)
+	defer cancel()
 
-	redisPort := os.Getenv("REDIS_PORT")
-	if redisPort == "" {
-		redisPort = "6379"
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.WithField("addr", redisAddr).WithError(err).Warn("Redis is unreachable at startup, starting degraded")
+		cb.Trip()
 	}
 
-	redisAddr := fmt.Sprintf("%s:%s", redisHost, redisPort)
+	return &RedisClient{Client: rdb, breaker: cb, log: log}, nil
+}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     redisAddr,
-		Password: cfg.Password, // Use password from config
-		DB:       cfg.DB,       // Use DB from config
-	})
+// Healthy reports whether Redis should be used. Callers with a fallback check it up front
+// instead of waiting for a command to fail.
+func (rc *RedisClient) Healthy() bool {
+	return rc.breaker.Healthy()
+}
+
+func (rc *RedisClient) CircuitState() State {
+	return rc.breaker.State()
+}
 
-	return &RedisClient{Client: rdb, log: log}, nil
+func (rc *RedisClient) OnStateChange(fn func(from, to State)) {
+	rc.breaker.OnStateChange(fn)
 }
 
 func (rc *RedisClient) Close() {
 	if rc.Client != nil {
 		err := rc.Client.Close()
 		if err != nil {
-			log.Printf("Gagal menutup koneksi Redis: %v", err)
+			rc.log.WithError(err).Warn("Failed to close Redis connection")
 		}
 	}
 }
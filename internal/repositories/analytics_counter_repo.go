@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+)
+
+const (
+	analyticsDateLayout = "2006-01-02"
+	analyticsDirtyKey   = "analytics:dirty"
+)
+
+type AnalyticsCounterRepository interface {
+	IncrementView(ctx context.Context, day time.Time, productID uuid.UUID, viewerKey string) error
+	IncrementAddToCart(ctx context.Context, day time.Time, productID uuid.UUID) error
+	IncrementSale(ctx context.Context, day time.Time, productID uuid.UUID, quantity int) error
+	PopDirty(ctx context.Context, count int64) ([]models.AnalyticsCounterKey, error)
+	MarkDirty(ctx context.Context, key models.AnalyticsCounterKey) error
+	GetCounters(ctx context.Context, key models.AnalyticsCounterKey) (*models.AnalyticsCounters, error)
+}
+
+type analyticsCounterRepositoryRedis struct {
+	redisClient *customRedis.RedisClient
+	counterTTL  time.Duration
+	log         *logrus.Logger
+}
+
+func NewAnalyticsCounterRepository(redisClient *customRedis.RedisClient, counterTTL time.Duration, log *logrus.Logger) AnalyticsCounterRepository {
+	return &analyticsCounterRepositoryRedis{
+		redisClient: redisClient,
+		counterTTL:  counterTTL,
+		log:         log,
+	}
+}
+
+func (r *analyticsCounterRepositoryRedis) getCounterKey(metric string, key models.AnalyticsCounterKey) string {
+	return fmt.Sprintf("analytics:%s:%s:%s", metric, key.Day.Format(analyticsDateLayout), key.ProductID)
+}
+
+func (r *analyticsCounterRepositoryRedis) IncrementView(ctx context.Context, day time.Time, productID uuid.UUID, viewerKey string) error {
+	key := models.AnalyticsCounterKey{Day: day, ProductID: productID}
+	viewsKey := r.getCounterKey("views", key)
+	viewersKey := r.getCounterKey("viewers", key)
+
+	pipe := r.redisClient.Client.TxPipeline()
+	pipe.Incr(ctx, viewsKey)
+	pipe.Expire(ctx, viewsKey, r.counterTTL)
+	if viewerKey != "" {
+		pipe.PFAdd(ctx, viewersKey, viewerKey)
+		pipe.Expire(ctx, viewersKey, r.counterTTL)
+	}
+	pipe.SAdd(ctx, analyticsDirtyKey, key.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", productID).WithError(err).Error("Failed to record product view")
+		return fmt.Errorf("failed to record product view: %w", err)
+	}
+
+	return nil
+}
+
+func (r *analyticsCounterRepositoryRedis) IncrementAddToCart(ctx context.Context, day time.Time, productID uuid.UUID) error {
+	key := models.AnalyticsCounterKey{Day: day, ProductID: productID}
+	cartsKey := r.getCounterKey("carts", key)
+
+	pipe := r.redisClient.Client.TxPipeline()
+	pipe.Incr(ctx, cartsKey)
+	pipe.Expire(ctx, cartsKey, r.counterTTL)
+	pipe.SAdd(ctx, analyticsDirtyKey, key.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", productID).WithError(err).Error("Failed to record add-to-cart")
+		return fmt.Errorf("failed to record add-to-cart: %w", err)
+	}
+
+	return nil
+}
+
+func (r *analyticsCounterRepositoryRedis) IncrementSale(ctx context.Context, day time.Time, productID uuid.UUID, quantity int) error {
+	key := models.AnalyticsCounterKey{Day: day, ProductID: productID}
+	ordersKey := r.getCounterKey("orders", key)
+	unitsKey := r.getCounterKey("units", key)
+
+	pipe := r.redisClient.Client.TxPipeline()
+	pipe.Incr(ctx, ordersKey)
+	pipe.Expire(ctx, ordersKey, r.counterTTL)
+	pipe.IncrBy(ctx, unitsKey, int64(quantity))
+	pipe.Expire(ctx, unitsKey, r.counterTTL)
+	pipe.SAdd(ctx, analyticsDirtyKey, key.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", productID).WithError(err).Error("Failed to record sale")
+		return fmt.Errorf("failed to record sale: %w", err)
+	}
+
+	return nil
+}
+
+// PopDirty removes and returns up to count product-days that received events since the last flush.
+func (r *analyticsCounterRepositoryRedis) PopDirty(ctx context.Context, count int64) ([]models.AnalyticsCounterKey, error) {
+	members, err := r.redisClient.Client.SPopN(ctx, analyticsDirtyKey, count).Result()
+	if err != nil && err != redis.Nil {
+		logctx.From(ctx, r.log).WithError(err).Error("Failed to pop dirty analytics keys")
+		return nil, fmt.Errorf("failed to pop dirty analytics keys: %w", err)
+	}
+
+	keys := make([]models.AnalyticsCounterKey, 0, len(members))
+	for _, member := range members {
+		key, err := parseAnalyticsCounterKey(member)
+		if err != nil {
+			logctx.From(ctx, r.log).WithField("member", member).WithError(err).Warn("Skipping malformed analytics key")
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *analyticsCounterRepositoryRedis) MarkDirty(ctx context.Context, key models.AnalyticsCounterKey) error {
+	if err := r.redisClient.Client.SAdd(ctx, analyticsDirtyKey, key.String()).Err(); err != nil {
+		return fmt.Errorf("failed to mark analytics key dirty: %w", err)
+	}
+
+	return nil
+}
+
+func (r *analyticsCounterRepositoryRedis) GetCounters(ctx context.Context, key models.AnalyticsCounterKey) (*models.AnalyticsCounters, error) {
+	pipe := r.redisClient.Client.Pipeline()
+	views := pipe.Get(ctx, r.getCounterKey("views", key))
+	viewers := pipe.PFCount(ctx, r.getCounterKey("viewers", key))
+	carts := pipe.Get(ctx, r.getCounterKey("carts", key))
+	orders := pipe.Get(ctx, r.getCounterKey("orders", key))
+	units := pipe.Get(ctx, r.getCounterKey("units", key))
+
+	// Missing counters come back as redis.Nil, which simply means zero for that metric.
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		logctx.From(ctx, r.log).WithField("product_id", key.ProductID).WithError(err).Error("Failed to read analytics counters")
+		return nil, fmt.Errorf("failed to read analytics counters: %w", err)
+	}
+
+	return &models.AnalyticsCounters{
+		Views:         counterValue(views),
+		UniqueViewers: int(viewers.Val()),
+		AddToCarts:    counterValue(carts),
+		Orders:        counterValue(orders),
+		UnitsSold:     counterValue(units),
+	}, nil
+}
+
+func counterValue(cmd *redis.StringCmd) int {
+	n, err := cmd.Int()
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+func parseAnalyticsCounterKey(member string) (models.AnalyticsCounterKey, error) {
+	parts := strings.SplitN(member, "|", 2)
+	if len(parts) != 2 {
+		return models.AnalyticsCounterKey{}, fmt.Errorf("unexpected analytics key format")
+	}
+
+	day, err := time.Parse(analyticsDateLayout, parts[0])
+	if err != nil {
+		return models.AnalyticsCounterKey{}, err
+	}
+
+	productID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return models.AnalyticsCounterKey{}, err
+	}
+
+	return models.AnalyticsCounterKey{Day: day, ProductID: productID}, nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+type AnalyticsRepository interface {
+	UpsertProductDailyStats(ctx context.Context, params *db.UpsertProductDailyStatsParams) (int64, error)
+	GetSellerDailyStats(ctx context.Context, params *db.GetSellerDailyStatsParams) ([]db.GetSellerDailyStatsRow, error)
+	GetSellerProductTotals(ctx context.Context, params *db.GetSellerProductTotalsParams) ([]db.GetSellerProductTotalsRow, error)
+	GetProductDailyStats(ctx context.Context, params *db.GetProductDailyStatsParams) ([]db.GetProductDailyStatsRow, error)
+}
+
+type analyticsRepository struct {
+	q   *db.Queries
+	log *logrus.Logger
+}
+
+func NewAnalyticsRepository(
+	q *db.Queries,
+	log *logrus.Logger,
+) AnalyticsRepository {
+	return &analyticsRepository{
+		q:   q,
+		log: log,
+	}
+}
+
+func (r *analyticsRepository) UpsertProductDailyStats(ctx context.Context, params *db.UpsertProductDailyStatsParams) (int64, error) {
+	rows, err := r.q.UpsertProductDailyStats(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"product_id": params.ProductID, "stat_date": params.StatDate}).WithError(err).Error("Failed to upsert product daily stats")
+		return 0, fmt.Errorf("failed to upsert product daily stats: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (r *analyticsRepository) GetSellerDailyStats(ctx context.Context, params *db.GetSellerDailyStatsParams) ([]db.GetSellerDailyStatsRow, error) {
+	rows, err := r.q.GetSellerDailyStats(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", params.SellerID).WithError(err).Error("Failed to receive seller daily stats from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *analyticsRepository) GetSellerProductTotals(ctx context.Context, params *db.GetSellerProductTotalsParams) ([]db.GetSellerProductTotalsRow, error) {
+	rows, err := r.q.GetSellerProductTotals(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", params.SellerID).WithError(err).Error("Failed to receive seller product totals from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *analyticsRepository) GetProductDailyStats(ctx context.Context, params *db.GetProductDailyStatsParams) ([]db.GetProductDailyStatsRow, error) {
+	rows, err := r.q.GetProductDailyStats(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", params.ProductID).WithError(err).Error("Failed to receive product daily stats from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
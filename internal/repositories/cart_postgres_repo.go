@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+// cartRepositoryPostgres keeps the carts table as a durable mirror of the Redis carts.
+type cartRepositoryPostgres struct {
+	q   *db.Queries
+	log *logrus.Logger
+}
+
+func newCartPostgresRepository(q *db.Queries, log *logrus.Logger) *cartRepositoryPostgres {
+	return &cartRepositoryPostgres{
+		q:   q,
+		log: log,
+	}
+}
+
+func (r *cartRepositoryPostgres) SaveItem(ctx context.Context, userID, productID uuid.UUID, item models.RedisCartItem) error {
+	updatedAt := item.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = item.AddedAt
+	}
+
+	err := r.q.UpsertCartItem(ctx, db.UpsertCartItemParams{
+		ID:          uuid.New(),
+		UserID:      userID,
+		ProductID:   productID,
+		Quantity:    int32(item.Quantity),
+		Description: sql.NullString{String: item.Description, Valid: item.Description != ""},
+		Checked:     item.Checked,
+		CreatedAt:   item.AddedAt,
+		UpdatedAt:   updatedAt,
+	})
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"user_id": userID, "product_id": productID}).WithError(err).Error("Failed to save cart item to DB")
+		return fmt.Errorf("failed to save cart item: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryPostgres) GetAllItems(ctx context.Context, userID uuid.UUID) (map[string]models.RedisCartItem, error) {
+	rows, err := r.q.GetCartItemsByUserID(ctx, userID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("user_id", userID).WithError(err).Error("Failed to retrieve cart from DB")
+		return nil, fmt.Errorf("failed to retrieve cart data: %w", err)
+	}
+
+	items := make(map[string]models.RedisCartItem, len(rows))
+	for _, row := range rows {
+		items[row.ProductID.String()] = models.RedisCartItem{
+			Quantity:    int(row.Quantity),
+			Description: row.Description.String,
+			Checked:     row.Checked,
+			AddedAt:     row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		}
+	}
+
+	return items, nil
+}
+
+func (r *cartRepositoryPostgres) RemoveItem(ctx context.Context, userID, productID uuid.UUID) error {
+	err := r.q.DeleteCartItem(ctx, db.DeleteCartItemParams{UserID: userID, ProductID: productID})
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"user_id": userID, "product_id": productID}).WithError(err).Error("Failed to delete cart item from DB")
+		return fmt.Errorf("failed to remove cart item: %w", err)
+	}
+
+	return nil
+}
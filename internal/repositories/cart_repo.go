@@ -2,15 +2,14 @@ package repositories
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
 )
 
@@ -21,108 +20,100 @@ type CartRepository interface {
 	RemoveItem(ctx context.Context, userID, productID uuid.UUID) error
 }
 
-type cartRepositoryRedis struct {
+// cartRepository serves carts from Redis and mirrors every write to Postgres. While Redis is
+// unavailable reads come from the mirror and writes fail with ErrCartUnavailable, so the mirror
+// never holds changes Redis does not know about.
+type cartRepository struct {
 	redisClient *customRedis.RedisClient
+	primary     *cartRepositoryRedis
+	mirror      *cartRepositoryPostgres
 	log         *logrus.Logger
 }
 
-func NewCartRepository(redisClient *customRedis.RedisClient, log *logrus.Logger) CartRepository {
-	return &cartRepositoryRedis{
+func NewCartRepository(redisClient *customRedis.RedisClient, q *db.Queries, log *logrus.Logger) CartRepository {
+	return &cartRepository{
 		redisClient: redisClient,
+		primary:     newCartRedisRepository(redisClient, log),
+		mirror:      newCartPostgresRepository(q, log),
 		log:         log,
 	}
 }
 
-func (r *cartRepositoryRedis) getCartKey(userID uuid.UUID) string {
-	return fmt.Sprintf("cart:%s", userID.String())
-}
-
-func (r *cartRepositoryRedis) AddItem(ctx context.Context, userID, productID uuid.UUID, item models.RedisCartItem) error {
-	cartKey := r.getCartKey(userID)
-
-	itemJSON, err := json.Marshal(item)
-	if err != nil {
-		r.log.WithError(err).Error("Failed to marshal basket items")
-		return fmt.Errorf("failed to process cart items: %w", err)
+func (r *cartRepository) AddItem(ctx context.Context, userID, productID uuid.UUID, item models.RedisCartItem) error {
+	if !r.redisClient.Healthy() {
+		return apperrors.ErrCartUnavailable
 	}
 
-	if err := r.redisClient.Client.HSet(ctx, cartKey, productID.String(), itemJSON).Err(); err != nil {
-		r.log.WithError(err).Error("Failed to save item to Redis")
-		return fmt.Errorf("failed to add item to cart: %w", err)
+	if err := r.primary.AddItem(ctx, userID, productID, item); err != nil {
+		return r.writeError(err)
 	}
 
+	r.mirrorSave(ctx, userID, productID, item)
 	return nil
 }
 
-func (r *cartRepositoryRedis) GetAllItems(ctx context.Context, userID uuid.UUID) (map[string]models.RedisCartItem, error) {
-	cartKey := r.getCartKey(userID)
-
-	itemsMapStr, err := r.redisClient.Client.HGetAll(ctx, cartKey).Result()
-	if err != nil {
-		r.log.WithError(err).Error("Failed to retrieve basket from Redis")
-		return nil, fmt.Errorf("failed to retrieve cart data: %w", err)
+func (r *cartRepository) GetAllItems(ctx context.Context, userID uuid.UUID) (map[string]models.RedisCartItem, error) {
+	if r.redisClient.Healthy() {
+		items, err := r.primary.GetAllItems(ctx, userID)
+		if err == nil || !customRedis.IsUnavailable(err) {
+			return items, err
+		}
 	}
 
-	resultMap := make(map[string]models.RedisCartItem, len(itemsMapStr))
-	for productID, itemJSON := range itemsMapStr {
-		var item models.RedisCartItem
-		if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
-			r.log.WithField("product_id", productID).WithError(err).Warn("Failed to unmarshal basket item, item skipped")
-			continue
-		}
-		resultMap[productID] = item
+	logctx.From(ctx, r.log).WithField("user_id", userID).Warn("Redis unavailable, reading cart from DB")
+
+	items, err := r.mirror.GetAllItems(ctx, userID)
+	if err != nil {
+		return nil, apperrors.ErrCartUnavailable.Wrap(err)
 	}
 
-	return resultMap, nil
+	return items, nil
 }
 
-func (r *cartRepositoryRedis) UpdateItem(ctx context.Context, userID, productID uuid.UUID, newQuantity int, newDescription string) error {
-	cartKey := r.getCartKey(userID)
-	productIDStr := productID.String()
-	logger := r.log.WithFields(logrus.Fields{"cart_key": cartKey, "product_id": productIDStr})
-
-	itemJSON, err := r.redisClient.Client.HGet(ctx, cartKey, productIDStr).Result()
-	if err == redis.Nil {
-		logger.Warn("Trying to update an item that is not in the cart")
-		return fmt.Errorf("item not found in cart")
+func (r *cartRepository) UpdateItem(ctx context.Context, userID, productID uuid.UUID, newQuantity int, newDescription string) error {
+	if !r.redisClient.Healthy() {
+		return apperrors.ErrCartUnavailable
 	}
+
+	item, err := r.primary.updateItem(ctx, userID, productID, newQuantity, newDescription)
 	if err != nil {
-		logger.WithError(err).Error("Failed to retrieve HGET item from Redis")
-		return fmt.Errorf("failed to retrieve item from cart: %w", err)
+		return r.writeError(err)
 	}
 
-	var item models.RedisCartItem
-	if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
-		logger.WithError(err).Error("Failed to unmarshal basket items from Redis")
-		return fmt.Errorf("corrupt basket data: %w", err)
-	}
+	r.mirrorSave(ctx, userID, productID, item)
+	return nil
+}
 
-	item.Quantity = newQuantity
-	item.Description = newDescription
-	item.UpdatedAt = time.Now()
+func (r *cartRepository) RemoveItem(ctx context.Context, userID, productID uuid.UUID) error {
+	if !r.redisClient.Healthy() {
+		return apperrors.ErrCartUnavailable
+	}
 
-	updatedItemJSON, err := json.Marshal(item)
-	if err != nil {
-		logger.WithError(err).Error("Failed to marshal updated basket items")
-		return fmt.Errorf("failed to process item update: %w", err)
+	if err := r.primary.RemoveItem(ctx, userID, productID); err != nil {
+		return r.writeError(err)
 	}
 
-	if err := r.redisClient.Client.HSet(ctx, cartKey, productIDStr, updatedItemJSON).Err(); err != nil {
-		logger.WithError(err).Error("Failed to update HSET item to Redis")
-		return fmt.Errorf("failed to save updates to the cart: %w", err)
+	if err := r.mirror.RemoveItem(ctx, userID, productID); err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"user_id": userID, "product_id": productID}).Warn("Cart mirror is behind Redis after a failed delete")
 	}
 
-	logger.Info("The quantity of items in Redis has been successfully updated.")
 	return nil
 }
 
-func (r *cartRepositoryRedis) RemoveItem(ctx context.Context, userID, productID uuid.UUID) error {
-	cartKey := r.getCartKey(userID)
+// ------- HELPERS -------
 
-	if err := r.redisClient.Client.HDel(ctx, cartKey, productID.String()).Err(); err != nil {
-		r.log.WithError(err).Error("Failed to delete item from Redis")
-		return fmt.Errorf("failed to remove item from cart: %w", err)
+// mirrorSave is best effort: Redis stays the source of truth, so a lagging mirror only matters
+// during a later outage.
+func (r *cartRepository) mirrorSave(ctx context.Context, userID, productID uuid.UUID, item models.RedisCartItem) {
+	if err := r.mirror.SaveItem(ctx, userID, productID, item); err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"user_id": userID, "product_id": productID}).Warn("Cart mirror is behind Redis after a failed write")
 	}
+}
 
-	return nil
+func (r *cartRepository) writeError(err error) error {
+	if customRedis.IsUnavailable(err) {
+		return apperrors.ErrCartUnavailable.Wrap(err)
+	}
+
+	return err
 }
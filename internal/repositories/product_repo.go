@@ -11,6 +11,7 @@ import (
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
 	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 )
 
 type ProductRepository interface {
@@ -22,10 +23,18 @@ type ProductRepository interface {
 	GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]db.GetProductsBySellerIDRow, error)
 	GetProductsByName(ctx context.Context, name string) ([]db.GetProductsByNameRow, error)
 	GetProductsByType(ctx context.Context, productType string) ([]db.GetProductsByTypeRow, error)
+	GetProductsBySellerIDAnyStatus(ctx context.Context, sellerID uuid.UUID) ([]db.GetProductsBySellerIDAnyStatusRow, error)
+	GetProductsBySellerIDPaged(ctx context.Context, params *db.GetProductsBySellerIDPagedParams) ([]db.GetProductsBySellerIDPagedRow, error)
+	GetSellerProductStats(ctx context.Context, sellerID uuid.UUID) (*db.GetSellerProductStatsRow, error)
+	GetSellerCategories(ctx context.Context, sellerID uuid.UUID) ([]db.GetSellerCategoriesRow, error)
+	GetProductsByStatus(ctx context.Context, status string) ([]db.GetProductsByStatusRow, error)
+	UpdateProductStatus(ctx context.Context, params *db.UpdateProductStatusParams) (*db.Product, error)
 	UpdateProduct(ctx context.Context, updateParams *db.UpdateProductParams) (*db.Product, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) (*db.Product, error)
 	DecreaseProductStock(ctx context.Context, tx *sql.Tx, productID uuid.UUID, quantity int32) (*db.Product, error)
 	IncreaseProductStock(ctx context.Context, tx *sql.Tx, params db.IncreaseProductStockParams) (db.Product, error)
+	AdjustProductRating(ctx context.Context, tx *sql.Tx, params db.AdjustProductRatingParams) (*db.Product, error)
+	RebuildProductRatings(ctx context.Context) (int64, error)
 }
 
 type productRepository struct {
@@ -53,7 +62,7 @@ func (r *productRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
 func (r *productRepository) CreateProduct(ctx context.Context, product *db.InsertProductParams) (*db.Product, error) {
 	row, err := r.q.InsertProduct(ctx, *product)
 	if err != nil {
-		r.log.WithField("product_id", product.ID).WithError(err).Error("Failed to create product in the database")
+		logctx.From(ctx, r.log).WithField("product_id", product.ID).WithError(err).Error("Failed to create product in the database")
 		return nil, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
@@ -65,7 +74,7 @@ func (r *productRepository) GetAllProducts(ctx context.Context) ([]db.GetAllProd
 
 	rows, err := r.q.GetAllProducts(ctx)
 	if err != nil {
-		r.log.WithFields(logrus.Fields{"error": err}).Error("Failed to receive orders from DB")
+		logctx.From(ctx, r.log).WithError(err).Error("Failed to receive products from DB")
 		return nil, err
 	}
 
@@ -77,7 +86,10 @@ func (r *productRepository) GetProductByID(ctx context.Context, id uuid.UUID) (*
 
 	row, err := r.q.GetProductByID(ctx, id)
 	if err != nil {
-		r.log.WithFields(logrus.Fields{"id": id, "error": err}).Error("Failed to receive product from DB")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrProductNotFound
+		}
+		logctx.From(ctx, r.log).WithField("product_id", id).WithError(err).Error("Failed to receive product from DB")
 		return nil, fmt.Errorf("failed to receive product from DB: %w", err)
 	}
 
@@ -89,7 +101,7 @@ func (r *productRepository) GetProductByIDs(ctx context.Context, ids []uuid.UUID
 
 	rows, err := r.q.GetProductByIDs(ctx, ids)
 	if err != nil {
-		r.log.WithFields(logrus.Fields{"product_ids": ids, "error": err}).Error("Failed to receive product from DB")
+		logctx.From(ctx, r.log).WithField("product_ids", ids).WithError(err).Error("Failed to receive products from DB")
 		return nil, err
 	}
 
@@ -101,7 +113,7 @@ func (r *productRepository) GetProductsBySellerID(ctx context.Context, sellerID
 
 	rows, err := r.q.GetProductsBySellerID(ctx, sellerID)
 	if err != nil {
-		r.log.WithFields(logrus.Fields{"seller_id": sellerID}).WithError(err).Error("Failed to receive products by seller ID from DB")
+		logctx.From(ctx, r.log).WithField("seller_id", sellerID).WithError(err).Error("Failed to receive products by seller ID from DB")
 		return nil, err
 	}
 
@@ -115,14 +127,10 @@ func (r *productRepository) GetProductsByName(ctx context.Context, name string)
 
 	rows, err := r.q.GetProductsByName(ctx, searchPattern)
 	if err != nil {
-		r.log.WithFields(logrus.Fields{
-			"name_query": name,
-			"error":      err,
-		}).Error("Failed to execute the GetProductsByName query in the database")
+		logctx.From(ctx, r.log).WithField("name_query", name).WithError(err).Error("Failed to receive products by name from DB")
 		return nil, err
 	}
 
-	r.log.WithFields(logrus.Fields{"name": name, "error": err}).Error("Product not found")
 	return rows, nil
 }
 
@@ -135,24 +143,80 @@ func (r *productRepository) GetProductsByType(ctx context.Context, productType s
 	})
 
 	if err != nil {
-		r.log.WithFields(logrus.Fields{
-			"name_query": productType,
-			"error":      err,
-		}).Error("Failed to execute the GetProductsByName query in the database")
+		logctx.From(ctx, r.log).WithField("product_type", productType).WithError(err).Error("Failed to receive products by type from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *productRepository) GetProductsBySellerIDAnyStatus(ctx context.Context, sellerID uuid.UUID) ([]db.GetProductsBySellerIDAnyStatusRow, error) {
+	rows, err := r.q.GetProductsBySellerIDAnyStatus(ctx, sellerID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", sellerID).WithError(err).Error("Failed to receive seller's own products from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *productRepository) GetProductsBySellerIDPaged(ctx context.Context, params *db.GetProductsBySellerIDPagedParams) ([]db.GetProductsBySellerIDPagedRow, error) {
+	rows, err := r.q.GetProductsBySellerIDPaged(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", params.SellerID).WithError(err).Error("Failed to receive paged seller products from DB")
 		return nil, err
 	}
 
-	r.log.WithFields(logrus.Fields{"name": productType, "error": err}).Error("Product not found")
 	return rows, nil
 }
 
+func (r *productRepository) GetSellerProductStats(ctx context.Context, sellerID uuid.UUID) (*db.GetSellerProductStatsRow, error) {
+	row, err := r.q.GetSellerProductStats(ctx, sellerID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", sellerID).WithError(err).Error("Failed to receive seller product stats from DB")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+func (r *productRepository) GetSellerCategories(ctx context.Context, sellerID uuid.UUID) ([]db.GetSellerCategoriesRow, error) {
+	rows, err := r.q.GetSellerCategories(ctx, sellerID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("seller_id", sellerID).WithError(err).Error("Failed to receive seller categories from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *productRepository) GetProductsByStatus(ctx context.Context, status string) ([]db.GetProductsByStatusRow, error) {
+	rows, err := r.q.GetProductsByStatus(ctx, status)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("status", status).WithError(err).Error("Failed to receive products by status from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *productRepository) UpdateProductStatus(ctx context.Context, params *db.UpdateProductStatusParams) (*db.Product, error) {
+	row, err := r.q.UpdateProductStatus(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"product_id": params.ID, "status": params.Status}).WithError(err).Error("Failed to update product status in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
 func (r *productRepository) UpdateProduct(ctx context.Context, updateParams *db.UpdateProductParams) (*db.Product, error) {
 	var row db.Product
 
 	row, err := r.q.UpdateProduct(ctx, *updateParams)
 
 	if err != nil {
-		r.log.WithField("product_id", updateParams.ID).WithError(err).Error("Failed to update product in the database")
+		logctx.From(ctx, r.log).WithField("product_id", updateParams.ID).WithError(err).Error("Failed to update product in the database")
 		return nil, err
 	}
 
@@ -164,7 +228,7 @@ func (r *productRepository) DeleteProduct(ctx context.Context, id uuid.UUID) (*d
 
 	row, err := r.q.DeleteProduct(ctx, id)
 	if err != nil {
-		r.log.WithField("product_id", id).WithError(err).Error("Failed to delete product in the database")
+		logctx.From(ctx, r.log).WithField("product_id", id).WithError(err).Error("Failed to delete product in the database")
 		return nil, err
 	}
 
@@ -193,7 +257,28 @@ func (r *productRepository) IncreaseProductStock(ctx context.Context, tx *sql.Tx
 	qtx := r.q.WithTx(tx)
 	updatedProduct, err := qtx.IncreaseProductStock(ctx, params)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.Product{}, apperrors.ErrProductNotFound
+		}
 		return db.Product{}, fmt.Errorf("failed to increase stock: %w", err)
 	}
 	return updatedProduct, nil
 }
+
+func (r *productRepository) AdjustProductRating(ctx context.Context, tx *sql.Tx, params db.AdjustProductRatingParams) (*db.Product, error) {
+	updatedProduct, err := r.q.WithTx(tx).AdjustProductRating(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust product rating: %w", err)
+	}
+	return &updatedProduct, nil
+}
+
+// RebuildProductRatings recomputes every product's rating aggregates from its reviews and returns
+// how many products had drifted.
+func (r *productRepository) RebuildProductRatings(ctx context.Context) (int64, error) {
+	rebuilt, err := r.q.RebuildProductRatings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild product ratings: %w", err)
+	}
+	return rebuilt, nil
+}
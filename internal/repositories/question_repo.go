@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, params *db.InsertQuestionParams) (*db.ProductQuestion, error)
+	GetQuestionByID(ctx context.Context, id uuid.UUID) (*db.ProductQuestion, error)
+	GetQuestionsByProductID(ctx context.Context, params *db.GetQuestionsByProductIDParams) ([]db.ProductQuestion, error)
+	CountQuestionsByProductID(ctx context.Context, productID uuid.UUID) (int64, error)
+	GetQuestionsByStatus(ctx context.Context, params *db.GetQuestionsByStatusParams) ([]db.ProductQuestion, error)
+	CountQuestionsByStatus(ctx context.Context, status string) (int64, error)
+	UpdateQuestionStatus(ctx context.Context, params *db.UpdateQuestionStatusParams) (*db.ProductQuestion, error)
+	CreateAnswer(ctx context.Context, params *db.InsertAnswerParams) (*db.ProductAnswer, error)
+	GetVisibleAnswersByQuestionIDs(ctx context.Context, questionIDs []uuid.UUID) ([]db.ProductAnswer, error)
+	UpdateAnswerStatus(ctx context.Context, params *db.UpdateAnswerStatusParams) (*db.UpdateAnswerStatusRow, error)
+}
+
+type questionRepository struct {
+	q   *db.Queries
+	log *logrus.Logger
+}
+
+func NewQuestionRepository(
+	q *db.Queries,
+	log *logrus.Logger,
+) QuestionRepository {
+	return &questionRepository{
+		q:   q,
+		log: log,
+	}
+}
+
+func (r *questionRepository) CreateQuestion(ctx context.Context, params *db.InsertQuestionParams) (*db.ProductQuestion, error) {
+	row, err := r.q.InsertQuestion(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"product_id": params.ProductID, "asker_id": params.AskerID}).WithError(err).Error("Failed to create question in the database")
+		return nil, fmt.Errorf("failed to create question: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *questionRepository) GetQuestionByID(ctx context.Context, id uuid.UUID) (*db.ProductQuestion, error) {
+	row, err := r.q.GetQuestionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrQuestionNotFound
+		}
+		logctx.From(ctx, r.log).WithField("question_id", id).WithError(err).Error("Failed to receive question from DB")
+		return nil, fmt.Errorf("failed to receive question from DB: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *questionRepository) GetQuestionsByProductID(ctx context.Context, params *db.GetQuestionsByProductIDParams) ([]db.ProductQuestion, error) {
+	rows, err := r.q.GetQuestionsByProductID(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", params.ProductID).WithError(err).Error("Failed to receive questions from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *questionRepository) CountQuestionsByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	count, err := r.q.CountQuestionsByProductID(ctx, productID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", productID).WithError(err).Error("Failed to count questions in DB")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *questionRepository) GetQuestionsByStatus(ctx context.Context, params *db.GetQuestionsByStatusParams) ([]db.ProductQuestion, error) {
+	rows, err := r.q.GetQuestionsByStatus(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("status", params.Status).WithError(err).Error("Failed to receive questions by status from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *questionRepository) CountQuestionsByStatus(ctx context.Context, status string) (int64, error) {
+	count, err := r.q.CountQuestionsByStatus(ctx, status)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("status", status).WithError(err).Error("Failed to count questions by status in DB")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *questionRepository) UpdateQuestionStatus(ctx context.Context, params *db.UpdateQuestionStatusParams) (*db.ProductQuestion, error) {
+	row, err := r.q.UpdateQuestionStatus(ctx, *params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrQuestionNotFound
+		}
+		logctx.From(ctx, r.log).WithField("question_id", params.ID).WithError(err).Error("Failed to update question status in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+func (r *questionRepository) CreateAnswer(ctx context.Context, params *db.InsertAnswerParams) (*db.ProductAnswer, error) {
+	row, err := r.q.InsertAnswer(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"question_id": params.QuestionID, "responder_id": params.ResponderID}).WithError(err).Error("Failed to create answer in the database")
+		return nil, fmt.Errorf("failed to create answer: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *questionRepository) GetVisibleAnswersByQuestionIDs(ctx context.Context, questionIDs []uuid.UUID) ([]db.ProductAnswer, error) {
+	rows, err := r.q.GetVisibleAnswersByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("question_ids", questionIDs).WithError(err).Error("Failed to receive answers from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// UpdateAnswerStatus also returns the status the answer had before the update.
+func (r *questionRepository) UpdateAnswerStatus(ctx context.Context, params *db.UpdateAnswerStatusParams) (*db.UpdateAnswerStatusRow, error) {
+	row, err := r.q.UpdateAnswerStatus(ctx, *params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrAnswerNotFound
+		}
+		logctx.From(ctx, r.log).WithField("answer_id", params.ID).WithError(err).Error("Failed to update answer status in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
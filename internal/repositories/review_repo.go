@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+const pgUniqueViolation = "23505"
+
+type ReviewRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CreateReview(ctx context.Context, tx *sql.Tx, params *db.InsertReviewParams) (*db.ProductReview, error)
+	GetReviewByID(ctx context.Context, id uuid.UUID) (*db.ProductReview, error)
+	LockReview(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*db.ProductReview, error)
+	LockReviewByUserAndProduct(ctx context.Context, tx *sql.Tx, userID, productID uuid.UUID) (*db.ProductReview, error)
+	GetReviewsByProductID(ctx context.Context, params *db.GetReviewsByProductIDParams) ([]db.ProductReview, error)
+	CountReviewsByProductID(ctx context.Context, productID uuid.UUID) (int64, error)
+	UpdateReview(ctx context.Context, tx *sql.Tx, params *db.UpdateReviewParams) (*db.ProductReview, error)
+	DeleteReview(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*db.ProductReview, error)
+	UpdateReviewReply(ctx context.Context, params *db.UpdateReviewReplyParams) (*db.ProductReview, error)
+	ReportReview(ctx context.Context, tx *sql.Tx, params *db.InsertReviewReportParams, hideThreshold int32) (*db.ProductReview, error)
+	HasVerifiedPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error)
+	RecordVerifiedPurchases(ctx context.Context, userID uuid.UUID, orderID string, productIDs []uuid.UUID) error
+}
+
+type reviewRepository struct {
+	db  *sql.DB
+	q   *db.Queries
+	log *logrus.Logger
+}
+
+func NewReviewRepository(
+	db *sql.DB,
+	q *db.Queries,
+	log *logrus.Logger,
+) ReviewRepository {
+	return &reviewRepository{
+		db:  db,
+		q:   q,
+		log: log,
+	}
+}
+
+func (r *reviewRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.db.BeginTx(ctx, nil)
+}
+
+func (r *reviewRepository) CreateReview(ctx context.Context, tx *sql.Tx, params *db.InsertReviewParams) (*db.ProductReview, error) {
+	row, err := r.q.WithTx(tx).InsertReview(ctx, *params)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return nil, apperrors.ErrReviewAlreadyExists
+		}
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"product_id": params.ProductID, "user_id": params.UserID}).WithError(err).Error("Failed to create review in the database")
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*db.ProductReview, error) {
+	row, err := r.q.GetReviewByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrReviewNotFound
+		}
+		logctx.From(ctx, r.log).WithField("review_id", id).WithError(err).Error("Failed to receive review from DB")
+		return nil, fmt.Errorf("failed to receive review from DB: %w", err)
+	}
+
+	return &row, nil
+}
+
+// LockReview reads a review and holds its row lock until tx ends, so the caller's view of the
+// rating and hidden flag cannot go stale before it adjusts the product aggregates.
+func (r *reviewRepository) LockReview(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*db.ProductReview, error) {
+	row, err := r.q.WithTx(tx).LockReviewByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrReviewNotFound
+		}
+		logctx.From(ctx, r.log).WithField("review_id", id).WithError(err).Error("Failed to lock review in DB")
+		return nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) LockReviewByUserAndProduct(ctx context.Context, tx *sql.Tx, userID, productID uuid.UUID) (*db.ProductReview, error) {
+	row, err := r.q.WithTx(tx).LockReviewByUserAndProduct(ctx, db.LockReviewByUserAndProductParams{
+		UserID:    userID,
+		ProductID: productID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrReviewNotFound
+		}
+		logctx.From(ctx, r.log).WithFields(logrus.Fields{"product_id": productID, "user_id": userID}).WithError(err).Error("Failed to lock review in DB")
+		return nil, fmt.Errorf("failed to lock review: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) GetReviewsByProductID(ctx context.Context, params *db.GetReviewsByProductIDParams) ([]db.ProductReview, error) {
+	rows, err := r.q.GetReviewsByProductID(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", params.ProductID).WithError(err).Error("Failed to receive reviews from DB")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *reviewRepository) CountReviewsByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	count, err := r.q.CountReviewsByProductID(ctx, productID)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("product_id", productID).WithError(err).Error("Failed to count reviews in DB")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *reviewRepository) UpdateReview(ctx context.Context, tx *sql.Tx, params *db.UpdateReviewParams) (*db.ProductReview, error) {
+	row, err := r.q.WithTx(tx).UpdateReview(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("review_id", params.ID).WithError(err).Error("Failed to update review in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) DeleteReview(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*db.ProductReview, error) {
+	row, err := r.q.WithTx(tx).DeleteReview(ctx, id)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("review_id", id).WithError(err).Error("Failed to delete review in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) UpdateReviewReply(ctx context.Context, params *db.UpdateReviewReplyParams) (*db.ProductReview, error) {
+	row, err := r.q.UpdateReviewReply(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("review_id", params.ID).WithError(err).Error("Failed to save seller reply in the database")
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// ReportReview records a report once per reporter and bumps the review's report counter,
+// hiding the review when the counter reaches hideThreshold.
+func (r *reviewRepository) ReportReview(ctx context.Context, tx *sql.Tx, params *db.InsertReviewReportParams, hideThreshold int32) (*db.ProductReview, error) {
+	qtx := r.q.WithTx(tx)
+
+	inserted, err := qtx.InsertReviewReport(ctx, *params)
+	if err != nil {
+		logctx.From(ctx, r.log).WithField("review_id", params.ReviewID).WithError(err).Error("Failed to save review report in the database")
+		return nil, fmt.Errorf("failed to report review: %w", err)
+	}
+
+	if inserted == 0 {
+		return nil, apperrors.ErrReviewAlreadyReported
+	}
+
+	row, err := qtx.IncrementReviewReportCount(ctx, db.IncrementReviewReportCountParams{
+		ID:            params.ReviewID,
+		HideThreshold: hideThreshold,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update review report count: %w", err)
+	}
+
+	return &row, nil
+}
+
+func (r *reviewRepository) HasVerifiedPurchase(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	return r.q.HasVerifiedPurchase(ctx, db.HasVerifiedPurchaseParams{
+		UserID:    userID,
+		ProductID: productID,
+	})
+}
+
+func (r *reviewRepository) RecordVerifiedPurchases(ctx context.Context, userID uuid.UUID, orderID string, productIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.q.WithTx(tx)
+	now := time.Now()
+
+	for _, productID := range productIDs {
+		if err := qtx.InsertVerifiedPurchase(ctx, db.InsertVerifiedPurchaseParams{
+			UserID:      userID,
+			ProductID:   productID,
+			OrderID:     orderID,
+			PurchasedAt: now,
+		}); err != nil {
+			return fmt.Errorf("failed to record verified purchase: %w", err)
+		}
+	}
+
+	if err := qtx.MarkReviewsVerified(ctx, db.MarkReviewsVerifiedParams{
+		UserID:     userID,
+		ProductIds: productIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to mark existing reviews as verified: %w", err)
+	}
+
+	return tx.Commit()
+}
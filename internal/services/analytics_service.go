@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+const analyticsFlushBatchSize = 500
+
+type AnalyticsService interface {
+	RecordProductView(ctx context.Context, productID uuid.UUID, viewerKey string)
+	RecordAddToCart(ctx context.Context, productID uuid.UUID)
+	RecordSale(ctx context.Context, productID uuid.UUID, quantity int)
+	FlushCounters(ctx context.Context) (int, error)
+	GetShopAnalytics(ctx context.Context, sellerID uuid.UUID, from, to time.Time) (*entities.ShopAnalytics, error)
+	GetProductAnalytics(ctx context.Context, productID, sellerID uuid.UUID, from, to time.Time) (*entities.ProductAnalytics, error)
+}
+
+type analyticsServiceImpl struct {
+	counterRepo   repositories.AnalyticsCounterRepository
+	analyticsRepo repositories.AnalyticsRepository
+	productRepo   repositories.ProductRepository
+	maxRangeDays  int
+	log           *logrus.Logger
+}
+
+func NewAnalyticsService(
+	counterRepo repositories.AnalyticsCounterRepository,
+	analyticsRepo repositories.AnalyticsRepository,
+	productRepo repositories.ProductRepository,
+	maxRangeDays int,
+	log *logrus.Logger,
+) AnalyticsService {
+	return &analyticsServiceImpl{
+		counterRepo:   counterRepo,
+		analyticsRepo: analyticsRepo,
+		productRepo:   productRepo,
+		maxRangeDays:  maxRangeDays,
+		log:           log,
+	}
+}
+
+func (s *analyticsServiceImpl) RecordProductView(ctx context.Context, productID uuid.UUID, viewerKey string) {
+	if err := s.counterRepo.IncrementView(ctx, today(), productID, viewerKey); err != nil {
+		logctx.From(ctx, s.log).WithField("product_id", productID).WithError(err).Warn("Product view not recorded")
+	}
+}
+
+func (s *analyticsServiceImpl) RecordAddToCart(ctx context.Context, productID uuid.UUID) {
+	if err := s.counterRepo.IncrementAddToCart(ctx, today(), productID); err != nil {
+		logctx.From(ctx, s.log).WithField("product_id", productID).WithError(err).Warn("Add-to-cart not recorded")
+	}
+}
+
+func (s *analyticsServiceImpl) RecordSale(ctx context.Context, productID uuid.UUID, quantity int) {
+	if err := s.counterRepo.IncrementSale(ctx, today(), productID, quantity); err != nil {
+		logctx.From(ctx, s.log).WithField("product_id", productID).WithError(err).Warn("Sale not recorded")
+	}
+}
+
+// FlushCounters copies every product-day touched since the last run from Redis into product_daily_stats.
+// Counters are written as absolute values, so a key that fails is simply re-queued for the next run.
+func (s *analyticsServiceImpl) FlushCounters(ctx context.Context) (int, error) {
+	var failed []models.AnalyticsCounterKey
+	flushed := 0
+
+	// Failed keys were already popped, so they go back on every exit path, including a run cut
+	// short by shutdown or a Redis error.
+	defer func() {
+		requeueCtx := context.WithoutCancel(ctx)
+		for _, key := range failed {
+			if err := s.counterRepo.MarkDirty(requeueCtx, key); err != nil {
+				logctx.From(ctx, s.log).WithField("key", key.String()).WithError(err).Error("Failed to re-queue analytics key")
+			}
+		}
+	}()
+
+	for {
+		keys, err := s.counterRepo.PopDirty(ctx, analyticsFlushBatchSize)
+		if err != nil {
+			return flushed, err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		for _, key := range keys {
+			if err := s.flushKey(ctx, key); err != nil {
+				logctx.From(ctx, s.log).WithField("key", key.String()).WithError(err).Warn("Analytics flush failed, will retry")
+				failed = append(failed, key)
+				continue
+			}
+			flushed++
+		}
+	}
+
+	return flushed, nil
+}
+
+func (s *analyticsServiceImpl) GetShopAnalytics(ctx context.Context, sellerID uuid.UUID, from, to time.Time) (*entities.ShopAnalytics, error) {
+	if err := s.validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	dailyRows, err := s.analyticsRepo.GetSellerDailyStats(ctx, &db.GetSellerDailyStatsParams{
+		SellerID: sellerID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve shop analytics for seller %s: %w", sellerID, err)
+	}
+
+	productRows, err := s.analyticsRepo.GetSellerProductTotals(ctx, &db.GetSellerProductTotalsParams{
+		SellerID: sellerID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve product analytics for seller %s: %w", sellerID, err)
+	}
+
+	series := make([]entities.DailyStat, 0, len(dailyRows))
+	for _, row := range dailyRows {
+		series = append(series, entities.DailyStat{
+			Date:          row.StatDate,
+			Views:         int(row.Views),
+			UniqueViewers: int(row.UniqueViewers),
+			AddToCarts:    int(row.AddToCarts),
+			Orders:        int(row.Orders),
+			UnitsSold:     int(row.UnitsSold),
+		})
+	}
+
+	products := make([]entities.ProductAnalytics, 0, len(productRows))
+	for _, row := range productRows {
+		products = append(products, entities.ProductAnalytics{
+			ProductID:   row.ProductID,
+			ProductName: row.Name,
+			Funnel: buildFunnel(entities.DailyStat{
+				Views:         int(row.Views),
+				UniqueViewers: int(row.UniqueViewers),
+				AddToCarts:    int(row.AddToCarts),
+				Orders:        int(row.Orders),
+				UnitsSold:     int(row.UnitsSold),
+			}),
+		})
+	}
+
+	return &entities.ShopAnalytics{
+		SellerID: sellerID,
+		From:     from,
+		To:       to,
+		Funnel:   buildFunnel(sumDailyStats(series)),
+		Series:   series,
+		Products: products,
+	}, nil
+}
+
+func (s *analyticsServiceImpl) GetProductAnalytics(ctx context.Context, productID, sellerID uuid.UUID, from, to time.Time) (*entities.ProductAnalytics, error) {
+	if err := s.validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to find product for analytics: %w", err)
+	}
+
+	if product.SellerID != sellerID {
+		return nil, apperrors.ErrProductNotBelongToSeller
+	}
+
+	rows, err := s.analyticsRepo.GetProductDailyStats(ctx, &db.GetProductDailyStatsParams{
+		ProductID: productID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve analytics for product %s: %w", productID, err)
+	}
+
+	series := make([]entities.DailyStat, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, entities.DailyStat{
+			Date:          row.StatDate,
+			Views:         int(row.Views),
+			UniqueViewers: int(row.UniqueViewers),
+			AddToCarts:    int(row.AddToCarts),
+			Orders:        int(row.Orders),
+			UnitsSold:     int(row.UnitsSold),
+		})
+	}
+
+	return &entities.ProductAnalytics{
+		ProductID:   productID,
+		ProductName: product.Name,
+		Funnel:      buildFunnel(sumDailyStats(series)),
+		Series:      series,
+	}, nil
+}
+
+// ------- HELPERS -------
+
+func (s *analyticsServiceImpl) flushKey(ctx context.Context, key models.AnalyticsCounterKey) error {
+	counters, err := s.counterRepo.GetCounters(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.analyticsRepo.UpsertProductDailyStats(ctx, &db.UpsertProductDailyStatsParams{
+		ProductID:     key.ProductID,
+		StatDate:      key.Day,
+		Views:         int32(counters.Views),
+		UniqueViewers: int32(counters.UniqueViewers),
+		AddToCarts:    int32(counters.AddToCarts),
+		Orders:        int32(counters.Orders),
+		UnitsSold:     int32(counters.UnitsSold),
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		logctx.From(ctx, s.log).WithField("product_id", key.ProductID).Debug("Dropping analytics for a product that no longer exists")
+	}
+
+	return nil
+}
+
+func (s *analyticsServiceImpl) validateRange(from, to time.Time) error {
+	if to.Before(from) {
+		return apperrors.ErrInvalidRequestPayload.WithDetails(apperrors.FieldError{Field: "from", Rule: "ltefield", Param: "to", Message: "must not be after 'to'"})
+	}
+
+	if s.maxRangeDays > 0 && to.Sub(from) > time.Duration(s.maxRangeDays)*24*time.Hour {
+		return apperrors.ErrInvalidRequestPayload.Withf("date range must not exceed %d days", s.maxRangeDays)
+	}
+
+	return nil
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func sumDailyStats(series []entities.DailyStat) entities.DailyStat {
+	var total entities.DailyStat
+	for _, day := range series {
+		total.Views += day.Views
+		total.UniqueViewers += day.UniqueViewers
+		total.AddToCarts += day.AddToCarts
+		total.Orders += day.Orders
+		total.UnitsSold += day.UnitsSold
+	}
+
+	return total
+}
+
+func buildFunnel(total entities.DailyStat) entities.Funnel {
+	return entities.Funnel{
+		Views:           total.Views,
+		UniqueViewers:   total.UniqueViewers,
+		AddToCarts:      total.AddToCarts,
+		Orders:          total.Orders,
+		UnitsSold:       total.UnitsSold,
+		ViewToCartRate:  rate(total.AddToCarts, total.Views),
+		CartToOrderRate: rate(total.Orders, total.AddToCarts),
+		ViewToOrderRate: rate(total.Orders, total.Views),
+	}
+}
+
+func rate(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	return float64(numerator) / float64(denominator)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+var errRedisDown = errors.New("redis down")
+
+func TestFlushCountersRequeuesFailedKeys(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	good := models.AnalyticsCounterKey{Day: day, ProductID: uuid.New()}
+	bad := models.AnalyticsCounterKey{Day: day, ProductID: uuid.New()}
+
+	tests := []struct {
+		name        string
+		batches     [][]models.AnalyticsCounterKey
+		popErr      error
+		cancel      bool
+		wantFlushed int
+		wantErr     error
+		wantQueued  []models.AnalyticsCounterKey
+	}{
+		{
+			name:        "failed key is re-queued",
+			batches:     [][]models.AnalyticsCounterKey{{good, bad}},
+			wantFlushed: 1,
+			wantQueued:  []models.AnalyticsCounterKey{bad},
+		},
+		{
+			name:        "Redis fails on a later batch",
+			batches:     [][]models.AnalyticsCounterKey{{good, bad}},
+			popErr:      errRedisDown,
+			wantFlushed: 1,
+			wantErr:     errRedisDown,
+			wantQueued:  []models.AnalyticsCounterKey{bad},
+		},
+		{
+			name:       "run cancelled mid-flush",
+			batches:    [][]models.AnalyticsCounterKey{{good, bad}},
+			cancel:     true,
+			wantErr:    context.Canceled,
+			wantQueued: []models.AnalyticsCounterKey{good, bad},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			counters := &fakeCounterRepo{batches: tt.batches, popErr: tt.popErr, failing: bad}
+			if tt.cancel {
+				counters.afterPop = cancel
+			}
+			svc := NewAnalyticsService(counters, fakeAnalyticsRepo{}, nil, 90, discardLogger())
+
+			flushed, err := svc.FlushCounters(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FlushCounters error = %v, want %v", err, tt.wantErr)
+			}
+			if flushed != tt.wantFlushed {
+				t.Errorf("flushed = %d, want %d", flushed, tt.wantFlushed)
+			}
+			if len(counters.queued) != len(tt.wantQueued) {
+				t.Fatalf("re-queued %v, want %v", counters.queued, tt.wantQueued)
+			}
+			for i, key := range tt.wantQueued {
+				if counters.queued[i] != key {
+					t.Errorf("re-queued[%d] = %s, want %s", i, counters.queued[i], key)
+				}
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+// fakeCounterRepo hands out batches, then popErr or an empty batch. Reads of failing always fail,
+// as do reads and writes on a cancelled context.
+type fakeCounterRepo struct {
+	repositories.AnalyticsCounterRepository
+
+	batches  [][]models.AnalyticsCounterKey
+	popErr   error
+	afterPop func()
+	failing  models.AnalyticsCounterKey
+	queued   []models.AnalyticsCounterKey
+}
+
+func (f *fakeCounterRepo) PopDirty(ctx context.Context, count int64) ([]models.AnalyticsCounterKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(f.batches) == 0 {
+		return nil, f.popErr
+	}
+
+	batch := f.batches[0]
+	f.batches = f.batches[1:]
+	if f.afterPop != nil {
+		f.afterPop()
+	}
+	return batch, nil
+}
+
+func (f *fakeCounterRepo) GetCounters(ctx context.Context, key models.AnalyticsCounterKey) (*models.AnalyticsCounters, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if key == f.failing {
+		return nil, errRedisDown
+	}
+	return &models.AnalyticsCounters{Views: 1}, nil
+}
+
+func (f *fakeCounterRepo) MarkDirty(ctx context.Context, key models.AnalyticsCounterKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.queued = append(f.queued, key)
+	return nil
+}
+
+type fakeAnalyticsRepo struct {
+	repositories.AnalyticsRepository
+}
+
+func (fakeAnalyticsRepo) UpsertProductDailyStats(ctx context.Context, params *db.UpsertProductDailyStatsParams) (int64, error) {
+	return 1, nil
+}
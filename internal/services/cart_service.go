@@ -3,20 +3,19 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
-
-	accountpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/account"
 )
 
 type CartSource interface {
@@ -31,39 +30,42 @@ type CartService interface {
 }
 
 type cartServiceImpl struct {
-	cartRepo      repositories.CartRepository
-	productSvc    ProductService
-	redisClient   *redis.RedisClient
-	accountClient *account.AccountClient
-	log           *logrus.Logger
+	cartRepo    repositories.CartRepository
+	productSvc  ProductService
+	redisClient *redis.RedisClient
+	sellers     gateways.SellerDirectory
+	analytics   gateways.AnalyticsRecorder
+	log         *logrus.Logger
 }
 
 func NewCartService(
 	repo repositories.CartRepository,
 	productSvc ProductService,
 	redis *redis.RedisClient,
-	accountClient *account.AccountClient,
+	sellers gateways.SellerDirectory,
+	analytics gateways.AnalyticsRecorder,
 	log *logrus.Logger,
 ) CartService {
 	return &cartServiceImpl{
-		cartRepo:      repo,
-		productSvc:    productSvc,
-		redisClient:   redis,
-		accountClient: accountClient,
-		log:           log,
+		cartRepo:    repo,
+		productSvc:  productSvc,
+		redisClient: redis,
+		sellers:     sellers,
+		analytics:   analytics,
+		log:         log,
 	}
 }
 
 func (s *cartServiceImpl) AddItemToCart(ctx context.Context, userID, productID uuid.UUID, req *models.CartRequest) error {
-	logger := s.log.WithFields(logrus.Fields{
+	logger := logctx.From(ctx, s.log).WithFields(logrus.Fields{
 		"user_id":    userID,
 		"product_id": productID,
 		"quantity":   req.Quantity,
 	})
-	logger.Info("Starting the process of adding items to the cart")
+	logger.Debug("Adding item to cart")
 
 	if req.Quantity <= 0 {
-		return fmt.Errorf("the quantity must be greater than 0")
+		return apperrors.ErrInvalidRequestPayload.WithDetails(apperrors.FieldError{Field: "quantity", Rule: "gt", Param: "0", Message: "must be greater than 0"})
 	}
 
 	item := models.RedisCartItem{
@@ -73,21 +75,21 @@ func (s *cartServiceImpl) AddItemToCart(ctx context.Context, userID, productID u
 		AddedAt:     time.Now(),
 	}
 
-	log.Println(item)
-
 	if err := s.cartRepo.AddItem(ctx, userID, productID, item); err != nil {
-		logger.WithError(err).Error("Gagal saat memanggil repository untuk menambah item")
+		logger.WithError(err).Error("Cart item not added")
 		return err
 	}
 
-	logger.Info("Item successfully added to cart")
+	logger.Info("Cart item added")
+
+	s.analytics.RecordAddToCart(ctx, productID)
 
 	return nil
 }
 
 func (s *cartServiceImpl) GetCartItemsByUserID(ctx context.Context, userID uuid.UUID) (*entities.Cart, error) {
-	logger := s.log.WithField("user_id", userID)
-	logger.Info("Retrieving items from the user's cart")
+	logger := logctx.From(ctx, s.log).WithField("user_id", userID)
+	logger.Debug("Loading cart")
 
 	itemsMap, err := s.cartRepo.GetAllItems(ctx, userID)
 	if err != nil {
@@ -113,51 +115,36 @@ func (s *cartServiceImpl) GetCartItemsByUserID(ctx context.Context, userID uuid.
 
 	productsResponse, err := s.productSvc.GetProductByIDs(ctx, productIDs)
 	if err != nil {
-		logger.WithError(err).Error("Gagal mengambil detail produk dari Product Service")
-		return nil, fmt.Errorf("gagal mengambil detail produk: %w", err)
+		logger.WithError(err).Error("Cart product details not loaded")
+		return nil, fmt.Errorf("failed to retrieve product details: %w", err)
 	}
 	productDetailsMap := make(map[string]*entities.Product)
 	for _, p := range productsResponse {
 		productDetailsMap[p.ID.String()] = &p
 	}
 
-	sellerIDMap := make(map[string]bool)
-	for _, productID := range productIDs {
-		productDetail, ok := productDetailsMap[productID.String()]
-		if !ok {
-			logger.WithField("product_id", productID.String()).Warn("Detail produk tidak ditemukan, item dilewati.")
-			continue
+	sellerIDMap := make(map[uuid.UUID]bool)
+	var sellerIDs []uuid.UUID
+	for _, productDetail := range productDetailsMap {
+		if !sellerIDMap[productDetail.SellerID] {
+			sellerIDMap[productDetail.SellerID] = true
+			sellerIDs = append(sellerIDs, productDetail.SellerID)
 		}
-
-		sellerIDMap[productDetail.SellerID.String()] = true
 	}
 
-	var sellerIDs []string
-	for sellerID := range sellerIDMap {
-		sellerIDs = append(sellerIDs, sellerID)
-	}
-
-	// account
-	accountDetailMap, err := s.fetchAccountDetails(ctx, sellerIDs)
-	if err != nil {
-		return nil, err
-	}
+	// Seller names are cosmetic: items whose seller could not be resolved keep an empty name.
+	sellerProfiles := s.sellers.GetSellerProfiles(ctx, sellerIDs)
 
 	finalItems := make([]entities.CartItem, 0, len(itemsMap))
 	for productIDStr, redisItem := range itemsMap {
 		productDetail, ok := productDetailsMap[productIDStr]
 		if !ok {
-			logger.WithField("product_id", productIDStr).Warn("Detail produk tidak ditemukan, item dilewati.")
-			continue
-		}
-		accountDetail, ok := accountDetailMap[productDetail.SellerID.String()]
-		if !ok {
-			logger.WithField("seller_id", productDetail.SellerID.String()).Warn("Detail penjual tidak ditemukan, item dilewati.")
+			logger.WithField("product_id", productIDStr).Warn("Cart item skipped, product not found")
 			continue
 		}
 
 		productID, _ := uuid.Parse(productIDStr)
-		sellerName := accountDetail.Name
+		sellerName := sellerProfiles[productDetail.SellerID].Name
 
 		assembledItem := toDomainCartItem(productID, redisItem, productDetail, sellerName)
 		finalItems = append(finalItems, *assembledItem)
@@ -165,37 +152,35 @@ func (s *cartServiceImpl) GetCartItemsByUserID(ctx context.Context, userID uuid.
 
 	finalCart := toDomainCart(userID, finalItems)
 
-	logger.Info("Successfully retrieved and enriched the basket items")
+	logger.WithField("items", len(finalItems)).Debug("Cart loaded")
 	return finalCart, nil
 }
 
 func (s *cartServiceImpl) UpdateItem(ctx context.Context, userID, productID uuid.UUID, newQuantity int, newDescription string) error {
-	logger := s.log.WithFields(logrus.Fields{"user_id": userID, "product_id": productID, "new_quantity": newQuantity})
+	logger := logctx.From(ctx, s.log).WithFields(logrus.Fields{"user_id": userID, "product_id": productID, "new_quantity": newQuantity})
 
 	if userID == uuid.Nil || productID == uuid.Nil {
-		return fmt.Errorf("invalid user ID or product ID")
+		return apperrors.ErrInvalidCartOperation.Withf("invalid user ID or product ID")
 	}
 
 	if newQuantity == 0 {
-		logger.Info("Quantity is 0, removing item from cart")
+		logger.Info("Cart item removed, quantity set to 0")
 		return s.cartRepo.RemoveItem(ctx, userID, productID)
 	}
 
 	if newQuantity < 0 {
-		return fmt.Errorf("kuantitas tidak boleh negatif")
+		return apperrors.ErrInvalidRequestPayload.WithDetails(apperrors.FieldError{Field: "quantity", Rule: "gte", Param: "0", Message: "must not be negative"})
 	}
 
-	logger.Info("Call Product Service for stock validation")
-
 	productsSvc, err := s.productSvc.GetProductByID(ctx, productID)
 	if err != nil {
-		logger.WithError(err).Error("Failed to retrieve product details from Product Service")
+		logger.WithError(err).Error("Cart update stock check failed")
 		return fmt.Errorf("failed to retrieve product details: %w", err)
 	}
 
 	if int(productsSvc.Stock) < newQuantity {
-		logger.Warnf("Stock is insufficient. Requested: %d, Available: %d", newQuantity, productsSvc.Stock)
-		return fmt.Errorf("insufficient stock for product '%s'", productsSvc.Name)
+		logger.WithField("available", productsSvc.Stock).Info("Cart update rejected, insufficient stock")
+		return apperrors.ErrInsufficientStock.Withf("only %d of '%s' left", productsSvc.Stock, productsSvc.Name)
 	}
 
 	return s.cartRepo.UpdateItem(ctx, userID, productID, newQuantity, newDescription)
@@ -203,34 +188,20 @@ func (s *cartServiceImpl) UpdateItem(ctx context.Context, userID, productID uuid
 
 func (s *cartServiceImpl) RemoveItemFromCart(ctx context.Context, userID, productID uuid.UUID) error {
 	if userID == uuid.Nil || productID == uuid.Nil {
-		return fmt.Errorf("invalid user ID or product ID")
+		return apperrors.ErrInvalidCartOperation.Withf("invalid user ID or product ID")
 	}
 
-	logger := s.log.WithFields(logrus.Fields{
+	logger := logctx.From(ctx, s.log).WithFields(logrus.Fields{
 		"user_id":    userID,
 		"product_id": productID,
 	})
-	logger.Info("Remove items from cart")
+	logger.Info("Cart item removed")
 
 	return s.cartRepo.RemoveItem(ctx, userID, productID)
 }
 
 // ------- HELPERS -------
 
-func (s *cartServiceImpl) fetchAccountDetails(ctx context.Context, sellerIDs []string) (map[string]*accountpb.User, error) {
-	accountResponse, err := s.accountClient.GetUsers(ctx, sellerIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve seller details via gRPC: %w", err)
-	}
-
-	accountDetailsMap := make(map[string]*accountpb.User)
-	for _, a := range accountResponse.Users {
-		accountDetailsMap[a.Id] = a
-	}
-
-	return accountDetailsMap, nil
-}
-
 func toDomainCartItem(
 	productID uuid.UUID,
 	redisItem models.RedisCartItem,
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	customRedis "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+
+	accountpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/account"
+)
+
+func TestCartSellerNames(t *testing.T) {
+	userID := uuid.New()
+	sellerID := uuid.New()
+	product := entities.Product{ID: uuid.New(), SellerID: sellerID, Name: "Gundam RX-78", Price: 500000, Stock: 3}
+
+	tests := []struct {
+		name           string
+		accountErr     error
+		wantSellerName string
+		wantCalls      int32
+	}{
+		{name: "resolved", wantSellerName: "Hobby Shop", wantCalls: 1},
+		{name: "account service unavailable", accountErr: status.Error(codes.Unavailable, "down"), wantCalls: 2},
+		{name: "account service rejects the request", accountErr: status.Error(codes.InvalidArgument, "bad ids"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &fakeAccountService{err: tt.accountErr, names: map[uuid.UUID]string{sellerID: "Hobby Shop"}}
+			svc := NewCartService(
+				&fakeCartRepo{items: map[string]models.RedisCartItem{product.ID.String(): {Quantity: 2}}},
+				&fakeProductService{products: []entities.Product{product}},
+				nil,
+				newTestSellerDirectory(t, accounts),
+				fakeAnalytics{},
+				discardLogger(),
+			)
+
+			cart, err := svc.GetCartItemsByUserID(context.Background(), userID)
+			if err != nil {
+				t.Fatalf("GetCartItemsByUserID: %v", err)
+			}
+			if len(cart.Items) != 1 {
+				t.Fatalf("cart has %d items, want 1", len(cart.Items))
+			}
+
+			item := cart.Items[0]
+			if item.SellerName != tt.wantSellerName {
+				t.Errorf("SellerName = %q, want %q", item.SellerName, tt.wantSellerName)
+			}
+			if item.SellerID != sellerID || item.ProductName != product.Name || item.Quantity != 2 {
+				t.Errorf("item = %+v, want the product and quantity kept", item)
+			}
+			if got := accounts.calls.Load(); got != tt.wantCalls {
+				t.Errorf("account calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+func discardLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+func newTestSellerDirectory(t *testing.T, service accountpb.AccountServiceClient) gateways.SellerDirectory {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	port, _ := strconv.Atoi(mr.Port())
+	log := discardLogger()
+
+	client, err := customRedis.NewRedisClient(&configs.RedisConfig{
+		Host:                    mr.Host(),
+		Port:                    port,
+		DialTimeout:             time.Second,
+		OperationTimeout:        time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, log)
+	if err != nil {
+		t.Fatalf("NewRedisClient: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	store := cache.NewStore(client, &configs.CacheConfig{
+		DefaultTTL:          time.Minute,
+		NegativeTTL:         10 * time.Second,
+		InvalidationChannel: "test:invalidate",
+	}, log)
+
+	accountClient := account.NewAccountClientFromService(service, nil, &configs.GrpcConfig{
+		CallTimeout:             time.Second,
+		MaxAttempts:             2,
+		RetryBackoff:            time.Millisecond,
+		BreakerFailureThreshold: 5,
+		BreakerOpenTimeout:      time.Second,
+	}, log)
+
+	return NewSellerDirectory(store, accountClient, time.Minute, log)
+}
+
+type fakeAccountService struct {
+	accountpb.AccountServiceClient
+
+	err   error
+	names map[uuid.UUID]string
+	calls atomic.Int32
+}
+
+func (f *fakeAccountService) GetUsers(ctx context.Context, in *accountpb.GetUsersRequest, opts ...grpc.CallOption) (*accountpb.GetUsersResponse, error) {
+	f.calls.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	res := &accountpb.GetUsersResponse{}
+	for _, id := range in.Ids {
+		parsed, _ := uuid.Parse(id)
+		if name, ok := f.names[parsed]; ok {
+			res.Users = append(res.Users, &accountpb.User{Id: id, Name: name})
+		}
+	}
+	return res, nil
+}
+
+type fakeCartRepo struct {
+	repositories.CartRepository
+
+	items map[string]models.RedisCartItem
+}
+
+func (f *fakeCartRepo) GetAllItems(ctx context.Context, userID uuid.UUID) (map[string]models.RedisCartItem, error) {
+	return f.items, nil
+}
+
+type fakeProductService struct {
+	ProductService
+
+	products []entities.Product
+}
+
+func (f *fakeProductService) GetProductByIDs(ctx context.Context, ids []uuid.UUID) ([]entities.Product, error) {
+	return f.products, nil
+}
+
+type fakeAnalytics struct {
+	gateways.AnalyticsRecorder
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"strings"
+)
+
+// KeywordScreener flags listings whose text contains any of the configured banned keywords.
+type KeywordScreener struct {
+	keywords []string
+}
+
+func NewKeywordScreener(keywords []string) *KeywordScreener {
+	normalized := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k != "" {
+			normalized = append(normalized, k)
+		}
+	}
+
+	return &KeywordScreener{keywords: normalized}
+}
+
+// Match returns every banned keyword found in the given texts, compared case-insensitively.
+func (k *KeywordScreener) Match(texts ...string) []string {
+	if k == nil || len(k.keywords) == 0 {
+		return nil
+	}
+
+	content := strings.ToLower(strings.Join(texts, " "))
+
+	var matched []string
+	for _, keyword := range k.keywords {
+		if strings.Contains(content, keyword) {
+			matched = append(matched, keyword)
+		}
+	}
+
+	return matched
+}
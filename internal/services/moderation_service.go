@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/messaging"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+type ModerationService interface {
+	SubmitForReview(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error)
+	ArchiveProduct(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error)
+	ApproveProduct(ctx context.Context, productID, adminID uuid.UUID) (*entities.Product, error)
+	RejectProduct(ctx context.Context, productID, adminID uuid.UUID, req *models.ModerationRequest) (*entities.Product, error)
+	SuspendProduct(ctx context.Context, productID, adminID uuid.UUID, req *models.ModerationRequest) (*entities.Product, error)
+	GetProductsByStatus(ctx context.Context, status entities.ProductStatus) ([]entities.Product, error)
+}
+
+type moderationServiceImpl struct {
+	productRepo   repositories.ProductRepository
+	productSvc    ProductService
+	screener      *KeywordScreener
+	notifier      gateways.NotificationSender
+	requireReview bool
+	validator     *validator.Validate
+	policy        *authz.Policy
+	log           *logrus.Logger
+}
+
+func NewModerationService(
+	productRepo repositories.ProductRepository,
+	productSvc ProductService,
+	screener *KeywordScreener,
+	notifier gateways.NotificationSender,
+	requireReview bool,
+	validator *validator.Validate,
+	policy *authz.Policy,
+	log *logrus.Logger,
+) ModerationService {
+	return &moderationServiceImpl{
+		productRepo:   productRepo,
+		productSvc:    productSvc,
+		screener:      screener,
+		notifier:      notifier,
+		requireReview: requireReview,
+		validator:     validator,
+		policy:        policy,
+		log:           log,
+	}
+}
+
+func (s *moderationServiceImpl) SubmitForReview(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error) {
+	existing, err := s.getOwnedProduct(ctx, productID, sellerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	target := entities.ProductStatusPublished
+	reason := ""
+
+	if matched := s.screener.Match(existing.Name, existing.Type, existing.Description); len(matched) > 0 {
+		target = entities.ProductStatusPendingReview
+		reason = flaggedReason(matched)
+	} else if s.requireReview || existing.Status == entities.ProductStatusSuspended {
+		// Only an admin can lift a suspension, through ApproveProduct.
+		target = entities.ProductStatusPendingReview
+	}
+
+	return s.transition(ctx, existing, target, reason, sellerID, false)
+}
+
+func (s *moderationServiceImpl) ArchiveProduct(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error) {
+	existing, err := s.getOwnedProduct(ctx, productID, sellerID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, existing, entities.ProductStatusArchived, "", sellerID, false)
+}
+
+func (s *moderationServiceImpl) ApproveProduct(ctx context.Context, productID, adminID uuid.UUID) (*entities.Product, error) {
+	existing, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Status != entities.ProductStatusPendingReview && existing.Status != entities.ProductStatusSuspended {
+		return nil, apperrors.ErrInvalidStatusTransition.Withf("cannot approve a %s product", existing.Status)
+	}
+
+	return s.transition(ctx, existing, entities.ProductStatusPublished, "", adminID, true)
+}
+
+func (s *moderationServiceImpl) RejectProduct(ctx context.Context, productID, adminID uuid.UUID, req *models.ModerationRequest) (*entities.Product, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	existing, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Status != entities.ProductStatusPendingReview {
+		return nil, apperrors.ErrInvalidStatusTransition.Withf("cannot reject a %s product", existing.Status)
+	}
+
+	return s.transition(ctx, existing, entities.ProductStatusDraft, req.Reason, adminID, true)
+}
+
+func (s *moderationServiceImpl) SuspendProduct(ctx context.Context, productID, adminID uuid.UUID, req *models.ModerationRequest) (*entities.Product, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	existing, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, existing, entities.ProductStatusSuspended, req.Reason, adminID, true)
+}
+
+func (s *moderationServiceImpl) GetProductsByStatus(ctx context.Context, status entities.ProductStatus) ([]entities.Product, error) {
+	if !status.IsValid() {
+		return nil, apperrors.ErrInvalidProductStatus
+	}
+
+	dbProducts, err := s.productRepo.GetProductsByStatus(ctx, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve products with status %s: %w", status, err)
+	}
+
+	return toDomainProducts(dbProducts), nil
+}
+
+// ------- HELPERS -------
+
+func (s *moderationServiceImpl) getProduct(ctx context.Context, productID uuid.UUID) (*entities.Product, error) {
+	dbProduct, err := s.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to find product for moderation: %w", err)
+	}
+
+	return toDomainProduct(dbProduct), nil
+}
+
+func (s *moderationServiceImpl) getOwnedProduct(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error) {
+	existing, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.AllowsOn(role, sellerID, authz.ProductWrite, existing.SellerID) {
+		return nil, apperrors.ErrProductNotBelongToSeller
+	}
+
+	return existing, nil
+}
+
+func (s *moderationServiceImpl) transition(ctx context.Context, existing *entities.Product, target entities.ProductStatus, reason string, actorID uuid.UUID, reviewed bool) (*entities.Product, error) {
+	if !existing.Status.CanTransitionTo(target) {
+		return nil, apperrors.ErrInvalidStatusTransition.Withf("%s -> %s", existing.Status, target)
+	}
+
+	params := &db.UpdateProductStatusParams{
+		ID:           existing.ID,
+		Status:       string(target),
+		StatusReason: sql.NullString{String: reason, Valid: reason != ""},
+	}
+	if reviewed {
+		params.ReviewedBy = uuid.NullUUID{UUID: actorID, Valid: true}
+		params.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	dbProduct, err := s.productRepo.UpdateProductStatus(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to update product status: %w", err)
+	}
+
+	if err := s.productSvc.InvalidateProductCache(ctx, existing.ID); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to clear product cache")
+	}
+
+	updated := toDomainProduct(dbProduct)
+	notifyStatusChanged(ctx, s.notifier, updated, existing.Status, actorID)
+
+	logctx.From(ctx, s.log).WithFields(logrus.Fields{
+		"product_id": existing.ID,
+		"from":       existing.Status,
+		"to":         target,
+		"actor_id":   actorID,
+	}).Info("Product status changed")
+
+	return updated, nil
+}
+
+func flaggedReason(matched []string) string {
+	return fmt.Sprintf("auto-flagged: listing contains banned keywords (%s)", strings.Join(matched, ", "))
+}
+
+func notifyStatusChanged(ctx context.Context, notifier gateways.NotificationSender, product *entities.Product, from entities.ProductStatus, actorID uuid.UUID) {
+	if notifier == nil {
+		return
+	}
+
+	notifier.Send(ctx, messaging.NotificationPayload{
+		Type:    messaging.ProductStatusChanged,
+		UserID:  product.SellerID,
+		Message: fmt.Sprintf("Product '%s' is now %s", product.Name, product.Status),
+		Data: models.ProductStatusChangedEvent{
+			ProductID:  product.ID.String(),
+			SellerID:   product.SellerID.String(),
+			FromStatus: string(from),
+			ToStatus:   string(product.Status),
+			Reason:     product.StatusReason,
+			ChangedBy:  actorID.String(),
+			ChangedAt:  product.UpdatedAt,
+		},
+	})
+}
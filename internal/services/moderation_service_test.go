@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+func TestSubmitForReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          entities.ProductStatus
+		description   string
+		requireReview bool
+		want          entities.ProductStatus
+	}{
+		{name: "draft is published", from: entities.ProductStatusDraft, want: entities.ProductStatusPublished},
+		{name: "draft waits for review when required", from: entities.ProductStatusDraft, requireReview: true, want: entities.ProductStatusPendingReview},
+		{name: "banned keyword is held for review", from: entities.ProductStatusDraft, description: "replica figure", want: entities.ProductStatusPendingReview},
+		{name: "suspended product goes back to review", from: entities.ProductStatusSuspended, want: entities.ProductStatusPendingReview},
+		{name: "suspended product goes back to review when required", from: entities.ProductStatusSuspended, requireReview: true, want: entities.ProductStatusPendingReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sellerID := uuid.New()
+			repo := &fakeProductRepo{row: db.GetProductByIDRow{
+				ID:       uuid.New(),
+				SellerID: sellerID,
+				Name:     "Zaku II",
+				Status:   string(tt.from),
+			}}
+			repo.row.Description.String, repo.row.Description.Valid = tt.description, tt.description != ""
+
+			svc := newTestModerationService(t, repo, tt.requireReview)
+
+			product, err := svc.SubmitForReview(context.Background(), repo.row.ID, sellerID, "seller")
+			if err != nil {
+				t.Fatalf("SubmitForReview: %v", err)
+			}
+			if product.Status != tt.want {
+				t.Errorf("status = %s, want %s", product.Status, tt.want)
+			}
+			if repo.updated == nil || repo.updated.ReviewedBy.Valid {
+				t.Errorf("update = %+v, want a status change not marked as reviewed", repo.updated)
+			}
+		})
+	}
+}
+
+func TestApproveLiftsSuspension(t *testing.T) {
+	repo := &fakeProductRepo{row: db.GetProductByIDRow{
+		ID:       uuid.New(),
+		SellerID: uuid.New(),
+		Name:     "Zaku II",
+		Status:   string(entities.ProductStatusSuspended),
+	}}
+	svc := newTestModerationService(t, repo, false)
+	adminID := uuid.New()
+
+	product, err := svc.ApproveProduct(context.Background(), repo.row.ID, adminID)
+	if err != nil {
+		t.Fatalf("ApproveProduct: %v", err)
+	}
+	if product.Status != entities.ProductStatusPublished {
+		t.Errorf("status = %s, want published", product.Status)
+	}
+	if repo.updated.ReviewedBy.UUID != adminID {
+		t.Errorf("reviewed by %s, want %s", repo.updated.ReviewedBy.UUID, adminID)
+	}
+}
+
+// ------- HELPERS -------
+
+func newTestModerationService(t *testing.T, repo repositories.ProductRepository, requireReview bool) ModerationService {
+	t.Helper()
+
+	policy, err := authz.NewPolicy([]string{"seller=product:write:own"})
+	if err != nil {
+		t.Fatalf("NewPolicy: %v", err)
+	}
+
+	return NewModerationService(
+		repo,
+		&fakeProductService{},
+		NewKeywordScreener([]string{"replica"}),
+		nil,
+		requireReview,
+		validator.New(),
+		policy,
+		discardLogger(),
+	)
+}
+
+// fakeProductRepo holds a single product and records the last status update.
+type fakeProductRepo struct {
+	repositories.ProductRepository
+
+	row     db.GetProductByIDRow
+	updated *db.UpdateProductStatusParams
+}
+
+func (f *fakeProductRepo) GetProductByID(ctx context.Context, id uuid.UUID) (*db.GetProductByIDRow, error) {
+	row := f.row
+	return &row, nil
+}
+
+func (f *fakeProductRepo) UpdateProductStatus(ctx context.Context, params *db.UpdateProductStatusParams) (*db.Product, error) {
+	f.updated = params
+	f.row.Status = params.Status
+	f.row.StatusReason = params.StatusReason
+
+	return &db.Product{
+		ID:           f.row.ID,
+		SellerID:     f.row.SellerID,
+		Name:         f.row.Name,
+		Description:  f.row.Description,
+		Status:       params.Status,
+		StatusReason: params.StatusReason,
+		ReviewedBy:   params.ReviewedBy,
+		ReviewedAt:   params.ReviewedAt,
+	}, nil
+}
+
+func (f *fakeProductService) InvalidateProductCache(ctx context.Context, productID uuid.UUID) error {
+	return nil
+}
@@ -0,0 +1,11 @@
+package services
+
+// MaxPage is the deepest page the list endpoints serve. It keeps the row offset, which the
+// queries take as an int32, far from overflowing at any page size.
+const MaxPage = 1000
+
+// pageOffset returns the row offset of a 1-based page, clamping page into [1, MaxPage].
+func pageOffset(page, perPage int) int32 {
+	page = min(max(page, 1), MaxPage)
+	return int32((page - 1) * perPage)
+}
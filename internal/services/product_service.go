@@ -2,10 +2,10 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -13,11 +13,16 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/background"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
 	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
-	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/redis"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/metrics"
 	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
 
 	productpb "github.com/RehanAthallahAzhar/tokohobby-protos/pb/product"
@@ -30,13 +35,19 @@ type ProductSource interface {
 		db.GetProductsByNameRow |
 		db.GetProductByIDRow |
 		db.GetProductByIDsRow |
-		db.GetProductsByTypeRow
+		db.GetProductsByTypeRow |
+		db.GetProductsBySellerIDAnyStatusRow |
+		db.GetProductsByStatusRow |
+		db.GetProductsBySellerIDPagedRow
 }
 
+const SortByRating = "rating"
+
 type ProductService interface {
 	CreateProduct(ctx context.Context, userID uuid.UUID, req *models.ProductRequest) (*entities.Product, error)
-	GetAllProducts(ctx context.Context) ([]entities.Product, error)
+	GetAllProducts(ctx context.Context, sortBy string) ([]entities.Product, error)
 	GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]entities.Product, error)
+	GetMyProducts(ctx context.Context, sellerID uuid.UUID) ([]entities.Product, error)
 	GetProductsByName(ctx context.Context, name string) ([]entities.Product, error)
 	GetProductsByType(ctx context.Context, productType string) ([]entities.Product, error)
 	GetProductByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
@@ -44,42 +55,60 @@ type ProductService interface {
 	UpdateProduct(ctx context.Context, req *models.ProductRequest, productID, sellerID uuid.UUID, role string) (*entities.Product, error)
 	DeleteProduct(ctx context.Context, productID, sellerID uuid.UUID, role string) (*entities.Product, error)
 	ResetAllProductCaches(ctx context.Context) error
+	InvalidateProductCache(ctx context.Context, productID uuid.UUID) error
 	DecreaseStock(ctx context.Context, items []*productpb.StockItem) ([]*entities.Product, error)
 	IncreaseStock(ctx context.Context, items []*productpb.StockItem) ([]*entities.Product, error)
 }
 
 type productServiceImpl struct {
 	productRepo    repositories.ProductRepository
-	redisClient    *redis.RedisClient
+	productCache   *cache.Cache[entities.Product]
+	listCache      *cache.Cache[[]entities.Product]
 	eventPublisher *validator.Validate
 	validator      *validator.Validate
+	screener       *KeywordScreener
+	notifier       gateways.NotificationSender
+	analytics      gateways.AnalyticsRecorder
+	tasks          *background.Tracker
+	policy         *authz.Policy
 	log            *logrus.Logger
 }
 
 func NewProductService(
 	productRepo repositories.ProductRepository,
-	redisClient *redis.RedisClient,
+	cacheStore *cache.Store,
 	validator *validator.Validate,
+	screener *KeywordScreener,
+	notifier gateways.NotificationSender,
+	analytics gateways.AnalyticsRecorder,
+	tasks *background.Tracker,
+	policy *authz.Policy,
 	log *logrus.Logger,
 ) ProductService {
 	return &productServiceImpl{
 		productRepo: productRepo,
-		redisClient: redisClient,
-		validator:   validator,
-		log:         log,
+		productCache: cache.New[entities.Product](cacheStore, cache.Options{
+			Namespace: "product",
+			NotFound:  apperrors.ErrProductNotFound,
+		}),
+		listCache: cache.New[[]entities.Product](cacheStore, cache.Options{
+			Namespace: "product_list",
+			// Lists are large; keep only the hottest few in memory.
+			L1Size: 512,
+		}),
+		validator: validator,
+		screener:  screener,
+		notifier:  notifier,
+		analytics: analytics,
+		tasks:     tasks,
+		policy:    policy,
+		log:       log,
 	}
 }
 
 func (s *productServiceImpl) CreateProduct(ctx context.Context, userID uuid.UUID, req *models.ProductRequest) (*entities.Product, error) {
 	if err := s.validator.Struct(req); err != nil {
-		validationErrors := err.(validator.ValidationErrors)
-
-		var errorMessages []string
-		for _, fieldErr := range validationErrors {
-			errorMessages = append(errorMessages, fmt.Sprintf("Field '%s' failed on the '%s' tag", fieldErr.Field(), fieldErr.Tag()))
-		}
-
-		return nil, fmt.Errorf("%w: %s", apperrors.ErrInvalidRequestPayload, strings.Join(errorMessages, ", "))
+		return nil, validationError(err)
 	}
 
 	product := &db.InsertProductParams{
@@ -91,6 +120,7 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, userID uuid.UUID
 		Discount:    helpers.IntToNullInt32(req.Discount),
 		Type:        helpers.StringToNullString(req.Type),
 		Description: helpers.StringToNullString(req.Description),
+		Status:      string(entities.ProductStatusDraft),
 	}
 
 	dbProduct, err := s.productRepo.CreateProduct(ctx, product)
@@ -99,179 +129,97 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, userID uuid.UUID
 	}
 
 	if err := s.InvalidateProductCache(ctx, dbProduct.ID); err != nil {
-		s.log.Errorf("Failed to clear product cache: %v", err)
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to clear product cache")
 	}
 
 	return toDomainProduct(dbProduct), nil
 }
 
-func (s *productServiceImpl) GetAllProducts(ctx context.Context) ([]entities.Product, error) {
-	var products []entities.Product
-	cacheKey := "all_products"
-
-	if val, err := s.redisClient.Client.Get(ctx, cacheKey).Result(); err == nil {
-		if err := json.Unmarshal([]byte(val), &products); err == nil {
-			s.log.Info("Hit Cache untuk GetAllProducts")
-			return products, nil
+func (s *productServiceImpl) GetAllProducts(ctx context.Context, sortBy string) ([]entities.Product, error) {
+	products, err := s.listCache.GetOrLoad(ctx, "all", func(ctx context.Context) ([]entities.Product, error) {
+		dbProducts, err := s.productRepo.GetAllProducts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("service: failed to retrieve all products: %w", err)
 		}
-	}
 
-	dbProducts, err := s.productRepo.GetAllProducts(ctx)
+		return toDomainProducts(dbProducts), nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("service: failed to retrieve all products: %w", err)
+		return nil, err
 	}
 
-	domainProduct := toDomainProducts(dbProducts)
-
-	jsonBytes, err := json.Marshal(domainProduct)
-	if err == nil {
-		if err := s.redisClient.Client.Set(ctx, cacheKey, jsonBytes, 5*time.Minute).Err(); err != nil {
-			s.log.WithField("key", cacheKey).Warn("Failed to set cache")
-		}
-	}
+	// Cached slices may be shared with concurrent callers, so sort a copy.
+	sorted := append([]entities.Product(nil), products...)
+	sortProducts(sorted, sortBy)
 
-	return domainProduct, nil
+	return sorted, nil
 }
 
 func (s *productServiceImpl) GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]entities.Product, error) {
-	var products []entities.Product
-	cacheKey := fmt.Sprintf("products_by_seller:%s", sellerID)
-
-	if val, err := s.redisClient.Client.Get(ctx, cacheKey).Result(); err == nil {
-		if err := json.Unmarshal([]byte(val), &products); err == nil {
-			s.log.WithField("seller_id", sellerID).Info("Hit Cache untuk GetProductsBySellerID")
-			return products, nil
-		}
-	}
-
-	dbProducts, err := s.productRepo.GetProductsBySellerID(ctx, sellerID)
-	if err != nil {
-		return nil, fmt.Errorf("service: failed to retrieve products by seller ID %s: %w", sellerID, err)
-	}
-
-	domainProduct := toDomainProducts(dbProducts)
-
-	jsonBytes, err := json.Marshal(domainProduct)
-	if err == nil {
-		if err := s.redisClient.Client.Set(ctx, cacheKey, jsonBytes, 5*time.Minute).Err(); err != nil {
-			s.log.WithField("key", cacheKey).Warn("Failed to set cache")
+	return s.listCache.GetOrLoad(ctx, fmt.Sprintf("seller:%s", sellerID), func(ctx context.Context) ([]entities.Product, error) {
+		dbProducts, err := s.productRepo.GetProductsBySellerID(ctx, sellerID)
+		if err != nil {
+			return nil, fmt.Errorf("service: failed to retrieve products by seller ID %s: %w", sellerID, err)
 		}
-	}
 
-	return domainProduct, nil
+		return toDomainProducts(dbProducts), nil
+	})
 }
 
-func (s *productServiceImpl) GetProductsByName(ctx context.Context, name string) ([]entities.Product, error) {
-	var products []entities.Product
-	cacheKey := fmt.Sprintf("products_by_name:%s", name)
-
-	if val, err := s.redisClient.Client.Get(ctx, cacheKey).Result(); err == nil {
-		if err := json.Unmarshal([]byte(val), &products); err == nil {
-			s.log.WithField("name", name).Info("Hit Cache untuk GetProductsByName")
-			return products, nil
-		}
-	}
-
-	dbProducts, err := s.productRepo.GetProductsByName(ctx, name)
+func (s *productServiceImpl) GetMyProducts(ctx context.Context, sellerID uuid.UUID) ([]entities.Product, error) {
+	dbProducts, err := s.productRepo.GetProductsBySellerIDAnyStatus(ctx, sellerID)
 	if err != nil {
-		return nil, fmt.Errorf("service: failed to retrieve products by name %s: %w", name, err)
-	}
-
-	if len(dbProducts) == 0 {
-		return []entities.Product{}, nil
+		return nil, fmt.Errorf("service: failed to retrieve own products for seller %s: %w", sellerID, err)
 	}
 
-	domainProducts := toDomainProducts(dbProducts)
+	return toDomainProducts(dbProducts), nil
+}
 
-	go func() {
-		jsonBytes, err := json.Marshal(domainProducts)
+func (s *productServiceImpl) GetProductsByName(ctx context.Context, name string) ([]entities.Product, error) {
+	return s.listCache.GetOrLoad(ctx, fmt.Sprintf("name:%s", name), func(ctx context.Context) ([]entities.Product, error) {
+		dbProducts, err := s.productRepo.GetProductsByName(ctx, name)
 		if err != nil {
-			s.log.Errorf("Failed to marshal products for caching: %v", err)
-			return
-		}
-
-		cacheCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-
-		if err := s.redisClient.Client.Set(cacheCtx, cacheKey, jsonBytes, 5*time.Minute).Err(); err != nil {
-			s.log.Warnf("Failed to set cache in background for key '%s': %v", cacheKey, err)
+			return nil, fmt.Errorf("service: failed to retrieve products by name %s: %w", name, err)
 		}
-	}()
 
-	return domainProducts, nil
+		return toDomainProducts(dbProducts), nil
+	})
 }
 
 func (s *productServiceImpl) GetProductsByType(ctx context.Context, productType string) ([]entities.Product, error) {
-	var products []entities.Product
-	cacheKey := fmt.Sprintf("products_by_type:%s", productType)
-
-	if val, err := s.redisClient.Client.Get(ctx, cacheKey).Result(); err == nil {
-		if err := json.Unmarshal([]byte(val), &products); err == nil {
-			s.log.WithField("name", productType).Info("Hit Cache untuk GetProductsByName")
-			return products, nil
-		}
-	}
-
-	dbProducts, err := s.productRepo.GetProductsByType(ctx, productType)
-	if err != nil {
-		return nil, fmt.Errorf("service: failed to retrieve products by name %s: %w", productType, err)
-	}
-
-	if len(dbProducts) == 0 {
-		return []entities.Product{}, nil
-	}
-
-	domainProducts := toDomainProducts(dbProducts)
-
-	go func() {
-		jsonBytes, err := json.Marshal(domainProducts)
+	return s.listCache.GetOrLoad(ctx, fmt.Sprintf("type:%s", productType), func(ctx context.Context) ([]entities.Product, error) {
+		dbProducts, err := s.productRepo.GetProductsByType(ctx, productType)
 		if err != nil {
-			s.log.Errorf("Failed to marshal products for caching: %v", err)
-			return
-		}
-
-		cacheCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-
-		if err := s.redisClient.Client.Set(cacheCtx, cacheKey, jsonBytes, 5*time.Minute).Err(); err != nil {
-			s.log.Warnf("Failed to set cache in background for key '%s': %v", cacheKey, err)
+			return nil, fmt.Errorf("service: failed to retrieve products by type %s: %w", productType, err)
 		}
-	}()
 
-	return domainProducts, nil
+		return toDomainProducts(dbProducts), nil
+	})
 }
 
 func (s *productServiceImpl) GetProductByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
-	var products *entities.Product
-	cacheKey := fmt.Sprintf("product:%s", id)
-
-	if val, err := s.redisClient.Client.Get(ctx, cacheKey).Result(); err == nil {
-		if err = json.Unmarshal([]byte(val), &products); err == nil {
-			s.log.WithField("product_id", id).Info("Hit Cache untuk GetProductByID")
-			return products, nil
+	product, err := s.productCache.GetOrLoad(ctx, id.String(), func(ctx context.Context) (entities.Product, error) {
+		dbProduct, err := s.productRepo.GetProductByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrProductNotFound) {
+				return entities.Product{}, apperrors.ErrProductNotFound
+			}
+			return entities.Product{}, fmt.Errorf("service: failed to retrieve product by ID: %w", err)
 		}
-	}
 
-	dbProduct, err := s.productRepo.GetProductByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("service: failed to retrieve product by ID: %w", err)
-	}
-
-	if dbProduct == nil {
-		return &entities.Product{}, nil
-	}
-
-	domainProduct := toDomainProduct(dbProduct)
-
-	jsonBytes, err := json.Marshal(domainProduct)
-	if err == nil {
-		if err = s.redisClient.Client.Set(ctx, cacheKey, jsonBytes, 5*time.Minute).Err(); err != nil {
-			s.log.WithField("key", cacheKey).Warn("Failed to set cache")
+		// Unpublished products are invisible to buyers and are remembered as misses.
+		domainProduct := toDomainProduct(dbProduct)
+		if domainProduct.Status != entities.ProductStatusPublished {
+			return entities.Product{}, apperrors.ErrProductNotFound
 		}
 
+		return *domainProduct, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return domainProduct, nil
+	return &product, nil
 }
 
 func (s *productServiceImpl) GetProductByIDs(ctx context.Context, ids []uuid.UUID) ([]entities.Product, error) {
@@ -279,90 +227,52 @@ func (s *productServiceImpl) GetProductByIDs(ctx context.Context, ids []uuid.UUI
 		return []entities.Product{}, nil
 	}
 
-	finalProducts := make([]entities.Product, 0, len(ids))
-	cacheKeys := make([]string, len(ids))
-	missedIDs := make(map[uuid.UUID]bool)
-
+	keys := make([]string, len(ids))
 	for i, id := range ids {
-		cacheKeys[i] = fmt.Sprintf("product:%s", id.String())
-		missedIDs[id] = true
+		keys[i] = id.String()
 	}
 
-	s.log.WithField("keys", cacheKeys).Info("Attempting to retrieve a product from the Redis cache with MGET")
-
-	cachedResults, err := s.redisClient.Client.MGet(ctx, cacheKeys...).Result()
-	if err != nil {
-		s.log.WithError(err).Warn("Failed to run MGET Redis. Will retrieve everything from the database..")
-	} else {
-		for i, result := range cachedResults {
-			if result != nil {
-				if val, ok := result.(string); ok {
-					var product entities.Product
-					if json.Unmarshal([]byte(val), &product) == nil {
-						finalProducts = append(finalProducts, product)
-						delete(missedIDs, ids[i])
-						s.log.WithField("product_id", ids[i]).Debug("Cache HIT untuk produk")
-					}
-				}
+	found, err := s.productCache.GetOrLoadMany(ctx, keys, func(ctx context.Context, missing []string) (map[string]entities.Product, error) {
+		missedIDs := make([]uuid.UUID, 0, len(missing))
+		for _, key := range missing {
+			id, err := uuid.Parse(key)
+			if err != nil {
+				continue
 			}
-		}
-	}
-
-	if len(missedIDs) > 0 {
-		missedIDSlice := make([]uuid.UUID, 0, len(missedIDs))
-		for id := range missedIDs {
-			missedIDSlice = append(missedIDSlice, id)
+			missedIDs = append(missedIDs, id)
 		}
 
-		s.log.WithField("missed_ids", missedIDSlice).Info("Cache MISS. Mengambil produk yang hilang dari database.")
+		logctx.From(ctx, s.log).WithField("missed_ids", missedIDs).Debug("Cache miss, loading products from database")
 
-		dbProducts, err := s.productRepo.GetProductByIDs(ctx, missedIDSlice)
+		dbProducts, err := s.productRepo.GetProductByIDs(ctx, missedIDs)
 		if err != nil {
 			return nil, fmt.Errorf("failed to retrieve product from database: %w", err)
 		}
 
-		domainProducts := toDomainProducts(dbProducts)
-
-		finalProducts = append(finalProducts, domainProducts...)
+		loaded := make(map[string]entities.Product, len(dbProducts))
+		for _, product := range toDomainProducts(dbProducts) {
+			loaded[product.ID.String()] = product
+		}
 
-		if len(domainProducts) > 0 {
-			pairs := make([]interface{}, 0, len(domainProducts)*2)
-			for _, product := range domainProducts {
-				cacheKey := fmt.Sprintf("product:%s", product.ID.String())
-				jsonBytes, err := json.Marshal(product)
-				if err == nil {
-					pairs = append(pairs, cacheKey, string(jsonBytes))
-				}
-			}
+		return loaded, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			if len(pairs) > 0 {
-				if err := s.redisClient.Client.MSet(ctx, pairs...).Err(); err != nil {
-					s.log.WithError(err).Error("Failed to run MSET to save new products to the Redis cache")
-				} else {
-					pipe := s.redisClient.Client.Pipeline()
-					ttl := 10 * time.Minute
-					for i := 0; i < len(pairs); i += 2 {
-						key := pairs[i].(string)
-						pipe.Expire(ctx, key, ttl)
-					}
-					if _, err := pipe.Exec(ctx); err != nil {
-						s.log.WithError(err).Error("Failed to set TTL for new product keys in the Redis cache")
-					}
-				}
-			}
+	products := make([]entities.Product, 0, len(found))
+	for _, key := range keys {
+		if product, ok := found[key]; ok {
+			products = append(products, product)
 		}
 	}
 
-	return finalProducts, nil
+	return products, nil
 }
+
 func (s *productServiceImpl) UpdateProduct(ctx context.Context, req *models.ProductRequest, productID, sellerID uuid.UUID, role string) (*entities.Product, error) {
 	if err := s.validator.Struct(req); err != nil {
-		validationErrors := err.(validator.ValidationErrors)
-		var errorMessages []string
-		for _, fieldErr := range validationErrors {
-			errorMessages = append(errorMessages, fmt.Sprintf("Field '%s' failed on the '%s' tag", fieldErr.Field(), fieldErr.Tag()))
-		}
-		return nil, fmt.Errorf("%w: %s", apperrors.ErrInvalidRequestPayload, strings.Join(errorMessages, ", "))
+		return nil, validationError(err)
 	}
 
 	existingProduct, err := s.productRepo.GetProductByID(ctx, productID)
@@ -370,8 +280,8 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, req *models.Prod
 		return nil, fmt.Errorf("service: failed to find product for update: %w", err)
 	}
 
-	if role != "admin" && existingProduct.SellerID != sellerID {
-		return nil, fmt.Errorf("service: product does not belong to this seller")
+	if !s.policy.AllowsOn(role, sellerID, authz.ProductWrite, existingProduct.SellerID) {
+		return nil, apperrors.ErrProductNotBelongToSeller
 	}
 
 	productParam := &db.UpdateProductParams{
@@ -390,8 +300,17 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, req *models.Prod
 		return nil, fmt.Errorf("service: failed to update product: %w", err)
 	}
 
+	if dbProduct.Status == string(entities.ProductStatusPublished) {
+		if matched := s.screener.Match(req.Name, req.Type, req.Description); len(matched) > 0 {
+			dbProduct, err = s.flagForReview(ctx, dbProduct, matched, sellerID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if err := s.InvalidateProductCache(ctx, productID); err != nil {
-		s.log.Errorf("Failed to clear product cache: %v", err)
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to clear product cache")
 	}
 
 	return toDomainProduct(dbProduct), nil
@@ -403,8 +322,8 @@ func (s *productServiceImpl) DeleteProduct(ctx context.Context, productID, selle
 		return nil, fmt.Errorf("service: failed to find product for deletion: %w", err)
 	}
 
-	if role != "admin" && existingProduct.SellerID != sellerID {
-		return nil, fmt.Errorf("service: product does not belong to this seller")
+	if !s.policy.AllowsOn(role, sellerID, authz.ProductWrite, existingProduct.SellerID) {
+		return nil, apperrors.ErrProductNotBelongToSeller
 	}
 
 	dbPproduct, err := s.productRepo.DeleteProduct(ctx, productID)
@@ -413,7 +332,7 @@ func (s *productServiceImpl) DeleteProduct(ctx context.Context, productID, selle
 	}
 
 	if err := s.InvalidateProductCache(ctx, productID); err != nil {
-		s.log.Errorf("Failed to clear product cache: %v", err)
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to clear product cache")
 	}
 
 	return toDomainProduct(dbPproduct), nil
@@ -422,6 +341,7 @@ func (s *productServiceImpl) DeleteProduct(ctx context.Context, productID, selle
 func (s *productServiceImpl) DecreaseStock(ctx context.Context, items []*productpb.StockItem) ([]*entities.Product, error) {
 	tx, err := s.productRepo.BeginTx(ctx)
 	if err != nil {
+		recordStockFailure(err)
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback() // Rollback
@@ -429,10 +349,17 @@ func (s *productServiceImpl) DecreaseStock(ctx context.Context, items []*product
 	updatedProducts := make([]*entities.Product, 0, len(items))
 
 	for _, item := range items {
-		productID, _ := uuid.Parse(item.ProductId)
+		productID, err := uuid.Parse(item.ProductId)
+		if err != nil {
+			return nil, apperrors.Invalid("product_id", "uuid", "must be a valid UUID")
+		}
 
 		dbProduct, err := s.productRepo.DecreaseProductStock(ctx, tx, productID, item.QuantityToDecrease)
 		if err != nil {
+			recordStockFailure(err)
+			if errors.Is(err, apperrors.ErrProductOutOfStock) {
+				return nil, apperrors.ErrProductOutOfStock.Withf("product %s", item.ProductId)
+			}
 			return nil, fmt.Errorf("failed to process stock for product %s: %w", item.ProductId, err) // Rollback
 		}
 
@@ -440,10 +367,17 @@ func (s *productServiceImpl) DecreaseStock(ctx context.Context, items []*product
 	}
 
 	if err := tx.Commit(); err != nil {
+		recordStockFailure(err)
 		return nil, fmt.Errorf("failed to commit stock update transaction: %w", err)
 	}
 
-	go s.InvalidateCachesAfterUpdate(ctx, updatedProducts)
+	for _, item := range items {
+		if productID, err := uuid.Parse(item.ProductId); err == nil {
+			s.analytics.RecordSale(ctx, productID, int(item.QuantityToDecrease))
+		}
+	}
+
+	s.tasks.Go("invalidate_product_caches", func() { s.InvalidateCachesAfterUpdate(ctx, updatedProducts) })
 
 	return updatedProducts, nil
 }
@@ -457,7 +391,10 @@ func (s *productServiceImpl) IncreaseStock(ctx context.Context, items []*product
 
 	updatedProducts := make([]*entities.Product, 0, len(items))
 	for _, item := range items {
-		productID, _ := uuid.Parse(item.ProductId)
+		productID, err := uuid.Parse(item.ProductId)
+		if err != nil {
+			return nil, apperrors.Invalid("product_id", "uuid", "must be a valid UUID")
+		}
 		params := db.IncreaseProductStockParams{
 			ProductID:          productID,
 			QuantityToIncrease: item.QuantityToDecrease,
@@ -465,6 +402,9 @@ func (s *productServiceImpl) IncreaseStock(ctx context.Context, items []*product
 
 		dbProduct, err := s.productRepo.IncreaseProductStock(ctx, tx, params)
 		if err != nil {
+			if errors.Is(err, apperrors.ErrProductNotFound) {
+				return nil, apperrors.ErrProductNotFound.Withf("product %s", item.ProductId)
+			}
 			return nil, fmt.Errorf("failed to process stock increase for %s: %w", item.ProductId, err)
 		}
 		updatedProducts = append(updatedProducts, toDomainProduct(&dbProduct))
@@ -474,12 +414,25 @@ func (s *productServiceImpl) IncreaseStock(ctx context.Context, items []*product
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	go s.InvalidateCachesAfterUpdate(ctx, updatedProducts)
+	s.tasks.Go("invalidate_product_caches", func() { s.InvalidateCachesAfterUpdate(ctx, updatedProducts) })
 	return updatedProducts, nil
 }
 
 // ------- HELPERS -------
+func recordStockFailure(err error) {
+	reason := metrics.StockInternal
+	if errors.Is(err, apperrors.ErrProductOutOfStock) {
+		reason = metrics.StockOutOfStock
+	}
+
+	metrics.StockDecrementFailures.WithLabelValues(reason).Inc()
+}
+
 func toDomainProduct[T ProductSource](dbProduct *T) *entities.Product {
+	if dbProduct == nil {
+		return nil
+	}
+
 	v := reflect.ValueOf(dbProduct)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -487,16 +440,33 @@ func toDomainProduct[T ProductSource](dbProduct *T) *entities.Product {
 	id := v.FieldByName("ID").Interface().(uuid.UUID)
 
 	return &entities.Product{
-		ID:          id,
-		SellerID:    v.FieldByName("SellerID").Interface().(uuid.UUID),
-		Name:        v.FieldByName("Name").Interface().(string),
-		Price:       helpers.ConvertNullInt32(v.FieldByName("Price")),
-		Stock:       helpers.ConvertNullInt32(v.FieldByName("Stock")),
-		Discount:    helpers.ConvertNullInt32(v.FieldByName("Discount")),
-		Type:        helpers.ConvertNullString(v.FieldByName("Type")),
-		Description: helpers.ConvertNullString(v.FieldByName("Description")),
-		CreatedAt:   v.FieldByName("CreatedAt").Interface().(time.Time),
-		UpdatedAt:   v.FieldByName("UpdatedAt").Interface().(time.Time),
+		ID:            id,
+		SellerID:      v.FieldByName("SellerID").Interface().(uuid.UUID),
+		Name:          v.FieldByName("Name").Interface().(string),
+		Price:         helpers.ConvertNullInt32(v.FieldByName("Price")),
+		Stock:         helpers.ConvertNullInt32(v.FieldByName("Stock")),
+		Discount:      helpers.ConvertNullInt32(v.FieldByName("Discount")),
+		Type:          helpers.ConvertNullString(v.FieldByName("Type")),
+		Description:   helpers.ConvertNullString(v.FieldByName("Description")),
+		Status:        entities.ProductStatus(v.FieldByName("Status").Interface().(string)),
+		StatusReason:  helpers.ConvertNullString(v.FieldByName("StatusReason")),
+		RatingAverage: v.FieldByName("RatingAvg").Interface().(float64),
+		RatingCount:   helpers.ConvertNullInt32(v.FieldByName("RatingCount")),
+		CreatedAt:     v.FieldByName("CreatedAt").Interface().(time.Time),
+		UpdatedAt:     v.FieldByName("UpdatedAt").Interface().(time.Time),
+	}
+}
+
+// sortProducts orders a product listing in place; unknown sort keys keep the database order.
+func sortProducts(products []entities.Product, sortBy string) {
+	switch sortBy {
+	case SortByRating:
+		sort.SliceStable(products, func(i, j int) bool {
+			if products[i].RatingAverage != products[j].RatingAverage {
+				return products[i].RatingAverage > products[j].RatingAverage
+			}
+			return products[i].RatingCount > products[j].RatingCount
+		})
 	}
 }
 
@@ -510,79 +480,71 @@ func toDomainProducts[T ProductSource](dbProducts []T) []entities.Product {
 	return products
 }
 
-func (s *productServiceImpl) InvalidateProductCache(ctx context.Context, productID uuid.UUID) error {
-	s.log.Infof("Invalidating caches for product %s and product list...", productID)
-
-	keysToDelete := []string{
-		fmt.Sprintf("product:%s", productID),
-		"all_products",
-	}
+// flagForReview moves a published product back to pending_review after an edit introduced banned keywords.
+func (s *productServiceImpl) flagForReview(ctx context.Context, dbProduct *db.Product, matched []string, actorID uuid.UUID) (*db.Product, error) {
+	reason := flaggedReason(matched)
 
-	err := s.redisClient.Client.Del(ctx, keysToDelete...).Err()
+	flagged, err := s.productRepo.UpdateProductStatus(ctx, &db.UpdateProductStatusParams{
+		ID:           dbProduct.ID,
+		Status:       string(entities.ProductStatusPendingReview),
+		StatusReason: helpers.StringToNullString(reason),
+	})
 	if err != nil {
-		s.log.Errorf("Failed to invalidate product cache keys (%v): %v", keysToDelete, err)
-		return err
+		return nil, fmt.Errorf("service: failed to flag product for review: %w", err)
 	}
 
-	s.log.Infof("Cache keys %v successfully invalidated.", keysToDelete)
-	return nil
-}
+	logctx.From(ctx, s.log).WithFields(logrus.Fields{"product_id": dbProduct.ID, "keywords": matched}).Warn("Product edit matched banned keywords, sent back to review")
+	notifyStatusChanged(ctx, s.notifier, toDomainProduct(flagged), entities.ProductStatusPublished, actorID)
 
-func (s *productServiceImpl) ResetAllProductCaches(ctx context.Context) error {
-	s.log.Info("Starting to reset ALL product caches...")
+	return flagged, nil
+}
 
-	if err := s.redisClient.Client.Del(ctx, "all_products").Err(); err != nil {
-		s.log.Errorf("Failed to delete main product list cache: %v", err)
+// InvalidateProductCache drops the product's own entry and every derived listing
+// (all products, by seller, by name, by type), since any of them may contain it.
+func (s *productServiceImpl) InvalidateProductCache(ctx context.Context, productID uuid.UUID) error {
+	if err := s.productCache.Delete(ctx, productID.String()); err != nil {
+		logctx.From(ctx, s.log).WithField("product_id", productID).WithError(err).Error("Failed to invalidate product cache")
+		return err
 	}
 
-	var cursor uint64
-	pattern := "product:*"
-
-	pipe := s.redisClient.Client.Pipeline()
-	keysFound := 0
+	if err := s.listCache.Invalidate(ctx); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to invalidate product list caches")
+		return err
+	}
 
-	for {
-		keys, nextCursor, err := s.redisClient.Client.Scan(ctx, cursor, pattern, 100).Result()
-		if err != nil {
-			s.log.Errorf("Error during Redis SCAN with pattern '%s': %v", pattern, err)
-			return err
-		}
+	return nil
+}
 
-		if len(keys) > 0 {
-			pipe.Del(ctx, keys...)
-			keysFound += len(keys)
-		}
+func (s *productServiceImpl) ResetAllProductCaches(ctx context.Context) error {
+	logctx.From(ctx, s.log).Info("Product caches reset requested")
 
-		cursor = nextCursor
-		if cursor == 0 {
-			break
-		}
+	if err := s.productCache.Invalidate(ctx); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to reset product caches")
+		return err
 	}
 
-	if _, err := pipe.Exec(ctx); err != nil {
-		s.log.Errorf("Failed to execute pipeline for deleting %d keys: %v", keysFound, err)
+	if err := s.listCache.Invalidate(ctx); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to reset product list caches")
 		return err
 	}
 
-	s.log.Infof("Successfully reset %d individual product caches and the main list cache.", keysFound)
 	return nil
 }
 
 func (s *productServiceImpl) InvalidateCachesAfterUpdate(ctx context.Context, updatedProducts []*entities.Product) {
-	s.log.Info("Invalidating product caches after stock update...")
-
-	keysToDel := []string{"all_products"}
+	cacheCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
 
+	keys := make([]string, 0, len(updatedProducts))
 	for _, p := range updatedProducts {
-		keysToDel = append(keysToDel, fmt.Sprintf("product:%s", p.ID.String()))
+		keys = append(keys, p.ID.String())
 	}
 
-	cacheCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if err := s.productCache.Delete(cacheCtx, keys...); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Warn("Failed to invalidate product caches")
+	}
 
-	if err := s.redisClient.Client.Del(cacheCtx, keysToDel...).Err(); err != nil {
-		s.log.Warnf("Failed to invalidate product caches: %v", err)
-	} else {
-		s.log.Infof("Successfully invalidated %d cache keys.", len(keysToDel))
+	if err := s.listCache.Invalidate(cacheCtx); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Warn("Failed to invalidate product list caches")
 	}
 }
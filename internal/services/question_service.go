@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/messaging"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+type QuestionService interface {
+	AskQuestion(ctx context.Context, askerID, productID uuid.UUID, req *models.QuestionRequest) (*entities.Question, error)
+	AnswerQuestion(ctx context.Context, questionID, responderID uuid.UUID, role string, req *models.AnswerRequest) (*entities.Answer, error)
+	GetProductQuestions(ctx context.Context, productID uuid.UUID, page, perPage int) ([]entities.Question, int, error)
+	GetQuestionsByStatus(ctx context.Context, status entities.ContentStatus, page, perPage int) ([]entities.Question, int, error)
+	SetQuestionStatus(ctx context.Context, questionID uuid.UUID, status entities.ContentStatus) (*entities.Question, error)
+	SetAnswerStatus(ctx context.Context, answerID uuid.UUID, status entities.ContentStatus) (*entities.Answer, error)
+}
+
+type questionServiceImpl struct {
+	questionRepo repositories.QuestionRepository
+	productRepo  repositories.ProductRepository
+	productSvc   ProductService
+	screener     *KeywordScreener
+	notifier     gateways.NotificationSender
+	validator    *validator.Validate
+	policy       *authz.Policy
+	log          *logrus.Logger
+}
+
+func NewQuestionService(
+	questionRepo repositories.QuestionRepository,
+	productRepo repositories.ProductRepository,
+	productSvc ProductService,
+	screener *KeywordScreener,
+	notifier gateways.NotificationSender,
+	validator *validator.Validate,
+	policy *authz.Policy,
+	log *logrus.Logger,
+) QuestionService {
+	return &questionServiceImpl{
+		questionRepo: questionRepo,
+		productRepo:  productRepo,
+		productSvc:   productSvc,
+		screener:     screener,
+		notifier:     notifier,
+		validator:    validator,
+		policy:       policy,
+		log:          log,
+	}
+}
+
+func (s *questionServiceImpl) AskQuestion(ctx context.Context, askerID, productID uuid.UUID, req *models.QuestionRequest) (*entities.Question, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	if _, err := s.productSvc.GetProductByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	dbQuestion, err := s.questionRepo.CreateQuestion(ctx, &db.InsertQuestionParams{
+		ID:        helpers.GenerateNewID(),
+		ProductID: productID,
+		AskerID:   askerID,
+		Body:      req.Body,
+		Status:    string(s.screenStatus(ctx, req.Body)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to ask question: %w", err)
+	}
+
+	return toDomainQuestion(dbQuestion), nil
+}
+
+func (s *questionServiceImpl) AnswerQuestion(ctx context.Context, questionID, responderID uuid.UUID, role string, req *models.AnswerRequest) (*entities.Answer, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	question, err := s.questionRepo.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, question.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to find product for question: %w", err)
+	}
+
+	if !s.policy.AllowsOn(role, responderID, authz.ProductWrite, product.SellerID) {
+		return nil, apperrors.ErrProductNotBelongToSeller
+	}
+
+	dbAnswer, err := s.questionRepo.CreateAnswer(ctx, &db.InsertAnswerParams{
+		ID:            helpers.GenerateNewID(),
+		QuestionID:    questionID,
+		ResponderID:   responderID,
+		ResponderRole: role,
+		Body:          req.Body,
+		Status:        string(s.screenStatus(ctx, req.Body)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to answer question: %w", err)
+	}
+
+	answer := toDomainAnswer(dbAnswer)
+	if answer.Status == entities.ContentStatusVisible {
+		s.notifyAsker(ctx, question, answer)
+	}
+
+	return answer, nil
+}
+
+func (s *questionServiceImpl) GetProductQuestions(ctx context.Context, productID uuid.UUID, page, perPage int) ([]entities.Question, int, error) {
+	dbQuestions, err := s.questionRepo.GetQuestionsByProductID(ctx, &db.GetQuestionsByProductIDParams{
+		ProductID:  productID,
+		PageLimit:  int32(perPage),
+		PageOffset: pageOffset(page, perPage),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to retrieve questions for product %s: %w", productID, err)
+	}
+
+	total, err := s.questionRepo.CountQuestionsByProductID(ctx, productID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to count questions for product %s: %w", productID, err)
+	}
+
+	questions, err := s.withAnswers(ctx, dbQuestions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return questions, int(total), nil
+}
+
+func (s *questionServiceImpl) GetQuestionsByStatus(ctx context.Context, status entities.ContentStatus, page, perPage int) ([]entities.Question, int, error) {
+	if !status.IsValid() {
+		return nil, 0, apperrors.ErrInvalidContentStatus
+	}
+
+	dbQuestions, err := s.questionRepo.GetQuestionsByStatus(ctx, &db.GetQuestionsByStatusParams{
+		Status:     string(status),
+		PageLimit:  int32(perPage),
+		PageOffset: pageOffset(page, perPage),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to retrieve %s questions: %w", status, err)
+	}
+
+	total, err := s.questionRepo.CountQuestionsByStatus(ctx, string(status))
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to count %s questions: %w", status, err)
+	}
+
+	questions := make([]entities.Question, 0, len(dbQuestions))
+	for i := range dbQuestions {
+		questions = append(questions, *toDomainQuestion(&dbQuestions[i]))
+	}
+
+	return questions, int(total), nil
+}
+
+func (s *questionServiceImpl) SetQuestionStatus(ctx context.Context, questionID uuid.UUID, status entities.ContentStatus) (*entities.Question, error) {
+	if !status.IsValid() {
+		return nil, apperrors.ErrInvalidContentStatus
+	}
+
+	dbQuestion, err := s.questionRepo.UpdateQuestionStatus(ctx, &db.UpdateQuestionStatusParams{
+		ID:     questionID,
+		Status: string(status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainQuestion(dbQuestion), nil
+}
+
+func (s *questionServiceImpl) SetAnswerStatus(ctx context.Context, answerID uuid.UUID, status entities.ContentStatus) (*entities.Answer, error) {
+	if !status.IsValid() {
+		return nil, apperrors.ErrInvalidContentStatus
+	}
+
+	row, err := s.questionRepo.UpdateAnswerStatus(ctx, &db.UpdateAnswerStatusParams{
+		ID:     answerID,
+		Status: string(status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answer := toDomainAnswer(&row.ProductAnswer)
+
+	// A screened answer reaches the asker only once a moderator makes it visible.
+	if answer.Status == entities.ContentStatusVisible && entities.ContentStatus(row.PreviousStatus) != entities.ContentStatusVisible {
+		question, err := s.questionRepo.GetQuestionByID(ctx, answer.QuestionID)
+		if err != nil {
+			logctx.From(ctx, s.log).WithField("answer_id", answerID).WithError(err).Warn("Asker not notified, question not loaded")
+			return answer, nil
+		}
+		s.notifyAsker(ctx, question, answer)
+	}
+
+	return answer, nil
+}
+
+// ------- HELPERS -------
+
+// screenStatus is the moderation hook for Q&A content: anything matching a banned keyword
+// is held as flagged until an admin makes it visible or hides it.
+func (s *questionServiceImpl) screenStatus(ctx context.Context, body string) entities.ContentStatus {
+	if matched := s.screener.Match(body); len(matched) > 0 {
+		logctx.From(ctx, s.log).WithField("keywords", matched).Warn("Q&A content matched banned keywords, flagged for moderation")
+		return entities.ContentStatusFlagged
+	}
+
+	return entities.ContentStatusVisible
+}
+
+func (s *questionServiceImpl) withAnswers(ctx context.Context, dbQuestions []db.ProductQuestion) ([]entities.Question, error) {
+	questions := make([]entities.Question, 0, len(dbQuestions))
+	if len(dbQuestions) == 0 {
+		return questions, nil
+	}
+
+	questionIDs := make([]uuid.UUID, 0, len(dbQuestions))
+	for _, q := range dbQuestions {
+		questionIDs = append(questionIDs, q.ID)
+	}
+
+	dbAnswers, err := s.questionRepo.GetVisibleAnswersByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve answers: %w", err)
+	}
+
+	answersByQuestion := make(map[uuid.UUID][]entities.Answer, len(dbQuestions))
+	for i := range dbAnswers {
+		answer := toDomainAnswer(&dbAnswers[i])
+		answersByQuestion[answer.QuestionID] = append(answersByQuestion[answer.QuestionID], *answer)
+	}
+
+	for i := range dbQuestions {
+		question := toDomainQuestion(&dbQuestions[i])
+		// Unanswered questions keep the empty slice so they serialize as [] rather than null.
+		if answers, ok := answersByQuestion[question.ID]; ok {
+			question.Answers = answers
+		}
+		questions = append(questions, *question)
+	}
+
+	return questions, nil
+}
+
+func (s *questionServiceImpl) notifyAsker(ctx context.Context, question *db.ProductQuestion, answer *entities.Answer) {
+	if s.notifier == nil {
+		return
+	}
+
+	s.notifier.Send(ctx, messaging.NotificationPayload{
+		Type:    messaging.QuestionAnswered,
+		UserID:  question.AskerID,
+		Message: "Your question has a new answer",
+		Data: models.QuestionAnsweredEvent{
+			QuestionID:  question.ID.String(),
+			ProductID:   question.ProductID.String(),
+			AnswerID:    answer.ID.String(),
+			ResponderID: answer.ResponderID.String(),
+			Answer:      answer.Body,
+		},
+	})
+}
+
+func toDomainQuestion(dbQuestion *db.ProductQuestion) *entities.Question {
+	return &entities.Question{
+		ID:        dbQuestion.ID,
+		ProductID: dbQuestion.ProductID,
+		AskerID:   dbQuestion.AskerID,
+		Body:      dbQuestion.Body,
+		Status:    entities.ContentStatus(dbQuestion.Status),
+		Answers:   []entities.Answer{},
+		CreatedAt: dbQuestion.CreatedAt,
+		UpdatedAt: dbQuestion.UpdatedAt,
+	}
+}
+
+func toDomainAnswer(dbAnswer *db.ProductAnswer) *entities.Answer {
+	return &entities.Answer{
+		ID:            dbAnswer.ID,
+		QuestionID:    dbAnswer.QuestionID,
+		ResponderID:   dbAnswer.ResponderID,
+		ResponderRole: dbAnswer.ResponderRole,
+		Body:          dbAnswer.Body,
+		Status:        entities.ContentStatus(dbAnswer.Status),
+		CreatedAt:     dbAnswer.CreatedAt,
+	}
+}
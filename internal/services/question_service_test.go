@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/messaging"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+func TestSetAnswerStatusNotifiesAsker(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       entities.ContentStatus
+		to         entities.ContentStatus
+		wantNotify bool
+	}{
+		{name: "flagged answer made visible", from: entities.ContentStatusFlagged, to: entities.ContentStatusVisible, wantNotify: true},
+		{name: "hidden answer made visible", from: entities.ContentStatusHidden, to: entities.ContentStatusVisible, wantNotify: true},
+		{name: "visible answer set visible again", from: entities.ContentStatusVisible, to: entities.ContentStatusVisible},
+		{name: "flagged answer hidden", from: entities.ContentStatusFlagged, to: entities.ContentStatusHidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := db.ProductQuestion{ID: uuid.New(), ProductID: uuid.New(), AskerID: uuid.New()}
+			repo := &fakeQuestionRepo{
+				question: question,
+				answer:   db.ProductAnswer{ID: uuid.New(), QuestionID: question.ID, Body: "Yes, it ships boxed.", Status: string(tt.from)},
+			}
+			notifier := &fakeNotifier{}
+			svc := NewQuestionService(repo, nil, nil, NewKeywordScreener(nil), notifier, validator.New(), nil, discardLogger())
+
+			answer, err := svc.SetAnswerStatus(context.Background(), repo.answer.ID, tt.to)
+			if err != nil {
+				t.Fatalf("SetAnswerStatus: %v", err)
+			}
+			if answer.Status != tt.to {
+				t.Errorf("status = %s, want %s", answer.Status, tt.to)
+			}
+
+			if !tt.wantNotify {
+				if len(notifier.sent) != 0 {
+					t.Errorf("sent %d notifications, want none", len(notifier.sent))
+				}
+				return
+			}
+			if len(notifier.sent) != 1 {
+				t.Fatalf("sent %d notifications, want 1", len(notifier.sent))
+			}
+			if got := notifier.sent[0]; got.Type != messaging.QuestionAnswered || got.UserID != question.AskerID {
+				t.Errorf("notification = %s to %s, want %s to the asker %s", got.Type, got.UserID, messaging.QuestionAnswered, question.AskerID)
+			}
+		})
+	}
+}
+
+// ------- HELPERS -------
+
+// fakeQuestionRepo holds one question and one of its answers.
+type fakeQuestionRepo struct {
+	repositories.QuestionRepository
+
+	question db.ProductQuestion
+	answer   db.ProductAnswer
+}
+
+func (f *fakeQuestionRepo) GetQuestionByID(ctx context.Context, id uuid.UUID) (*db.ProductQuestion, error) {
+	question := f.question
+	return &question, nil
+}
+
+func (f *fakeQuestionRepo) UpdateAnswerStatus(ctx context.Context, params *db.UpdateAnswerStatusParams) (*db.UpdateAnswerStatusRow, error) {
+	previous := f.answer.Status
+	f.answer.Status = params.Status
+	return &db.UpdateAnswerStatusRow{ProductAnswer: f.answer, PreviousStatus: previous}, nil
+}
+
+type fakeNotifier struct {
+	sent []messaging.NotificationPayload
+}
+
+func (f *fakeNotifier) Send(ctx context.Context, payload messaging.NotificationPayload) {
+	f.sent = append(f.sent, payload)
+}
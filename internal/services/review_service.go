@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/helpers"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/models"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/authz"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, productID uuid.UUID, req *models.ReviewRequest) (*entities.Review, error)
+	UpdateReview(ctx context.Context, userID, productID uuid.UUID, req *models.ReviewRequest) (*entities.Review, error)
+	DeleteReview(ctx context.Context, userID, productID uuid.UUID) error
+	GetProductReviews(ctx context.Context, productID uuid.UUID, sortBy string, page, perPage int) ([]entities.Review, int, error)
+	ReplyToReview(ctx context.Context, reviewID, sellerID uuid.UUID, role string, req *models.ReviewReplyRequest) (*entities.Review, error)
+	ReportReview(ctx context.Context, reviewID, reporterID uuid.UUID, req *models.ReviewReportRequest) error
+	RecordVerifiedPurchase(ctx context.Context, event *models.OrderCreatedEvent) error
+}
+
+type reviewServiceImpl struct {
+	reviewRepo          repositories.ReviewRepository
+	productRepo         repositories.ProductRepository
+	productSvc          ProductService
+	reportHideThreshold int
+	validator           *validator.Validate
+	policy              *authz.Policy
+	log                 *logrus.Logger
+}
+
+func NewReviewService(
+	reviewRepo repositories.ReviewRepository,
+	productRepo repositories.ProductRepository,
+	productSvc ProductService,
+	reportHideThreshold int,
+	validator *validator.Validate,
+	policy *authz.Policy,
+	log *logrus.Logger,
+) ReviewService {
+	return &reviewServiceImpl{
+		reviewRepo:          reviewRepo,
+		productRepo:         productRepo,
+		productSvc:          productSvc,
+		reportHideThreshold: reportHideThreshold,
+		validator:           validator,
+		policy:              policy,
+		log:                 log,
+	}
+}
+
+func (s *reviewServiceImpl) CreateReview(ctx context.Context, userID, productID uuid.UUID, req *models.ReviewRequest) (*entities.Review, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	product, err := s.productSvc.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.SellerID == userID {
+		return nil, apperrors.ErrCannotReviewOwnProduct
+	}
+
+	verified, err := s.reviewRepo.HasVerifiedPurchase(ctx, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to check verified purchase: %w", err)
+	}
+
+	tx, err := s.reviewRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dbReview, err := s.reviewRepo.CreateReview(ctx, tx, &db.InsertReviewParams{
+		ID:               helpers.GenerateNewID(),
+		ProductID:        productID,
+		UserID:           userID,
+		Rating:           int16(req.Rating),
+		Body:             helpers.StringToNullString(req.Body),
+		VerifiedPurchase: verified,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.adjustRating(ctx, tx, productID, 1, req.Rating); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit review: %w", err)
+	}
+
+	s.invalidateProduct(ctx, productID)
+
+	return toDomainReview(dbReview), nil
+}
+
+func (s *reviewServiceImpl) UpdateReview(ctx context.Context, userID, productID uuid.UUID, req *models.ReviewRequest) (*entities.Review, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	tx, err := s.reviewRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := s.reviewRepo.LockReviewByUserAndProduct(ctx, tx, userID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbReview, err := s.reviewRepo.UpdateReview(ctx, tx, &db.UpdateReviewParams{
+		ID:     existing.ID,
+		Rating: int16(req.Rating),
+		Body:   helpers.StringToNullString(req.Body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to update review: %w", err)
+	}
+
+	if delta := req.Rating - int(existing.Rating); delta != 0 && !existing.Hidden {
+		if err := s.adjustRating(ctx, tx, productID, 0, delta); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit review update: %w", err)
+	}
+
+	s.invalidateProduct(ctx, productID)
+
+	return toDomainReview(dbReview), nil
+}
+
+func (s *reviewServiceImpl) DeleteReview(ctx context.Context, userID, productID uuid.UUID) error {
+	tx, err := s.reviewRepo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := s.reviewRepo.LockReviewByUserAndProduct(ctx, tx, userID, productID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.reviewRepo.DeleteReview(ctx, tx, existing.ID); err != nil {
+		return fmt.Errorf("service: failed to delete review: %w", err)
+	}
+
+	// A hidden review was already taken out of the aggregates when it was hidden.
+	if !existing.Hidden {
+		if err := s.adjustRating(ctx, tx, productID, -1, -int(existing.Rating)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review deletion: %w", err)
+	}
+
+	s.invalidateProduct(ctx, productID)
+
+	return nil
+}
+
+func (s *reviewServiceImpl) GetProductReviews(ctx context.Context, productID uuid.UUID, sortBy string, page, perPage int) ([]entities.Review, int, error) {
+	dbReviews, err := s.reviewRepo.GetReviewsByProductID(ctx, &db.GetReviewsByProductIDParams{
+		ProductID:  productID,
+		Sort:       sortBy,
+		PageLimit:  int32(perPage),
+		PageOffset: pageOffset(page, perPage),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to retrieve reviews for product %s: %w", productID, err)
+	}
+
+	total, err := s.reviewRepo.CountReviewsByProductID(ctx, productID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to count reviews for product %s: %w", productID, err)
+	}
+
+	reviews := make([]entities.Review, 0, len(dbReviews))
+	for i := range dbReviews {
+		reviews = append(reviews, *toDomainReview(&dbReviews[i]))
+	}
+
+	return reviews, int(total), nil
+}
+
+func (s *reviewServiceImpl) ReplyToReview(ctx context.Context, reviewID, sellerID uuid.UUID, role string, req *models.ReviewReplyRequest) (*entities.Review, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	review, err := s.reviewRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, review.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to find reviewed product: %w", err)
+	}
+
+	if !s.policy.AllowsOn(role, sellerID, authz.ProductWrite, product.SellerID) {
+		return nil, apperrors.ErrProductNotBelongToSeller
+	}
+
+	dbReview, err := s.reviewRepo.UpdateReviewReply(ctx, &db.UpdateReviewReplyParams{
+		ID:          reviewID,
+		SellerReply: helpers.StringToNullString(req.Reply),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to reply to review: %w", err)
+	}
+
+	return toDomainReview(dbReview), nil
+}
+
+func (s *reviewServiceImpl) ReportReview(ctx context.Context, reviewID, reporterID uuid.UUID, req *models.ReviewReportRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	tx, err := s.reviewRepo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := s.reviewRepo.LockReview(ctx, tx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	dbReview, err := s.reviewRepo.ReportReview(ctx, tx, &db.InsertReviewReportParams{
+		ReviewID:   reviewID,
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+	}, int32(s.reportHideThreshold))
+	if err != nil {
+		return err
+	}
+
+	// Hidden reviews no longer count towards the product rating, so the review leaves the
+	// aggregates in the same transaction that hides it.
+	hiddenNow := dbReview.Hidden && !review.Hidden
+	if hiddenNow {
+		if err := s.adjustRating(ctx, tx, review.ProductID, -1, -int(review.Rating)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review report: %w", err)
+	}
+
+	if hiddenNow {
+		s.invalidateProduct(ctx, review.ProductID)
+		logctx.From(ctx, s.log).WithFields(logrus.Fields{"review_id": reviewID, "report_count": dbReview.ReportCount}).Warn("Review hidden after reaching the report threshold")
+	}
+
+	return nil
+}
+
+func (s *reviewServiceImpl) RecordVerifiedPurchase(ctx context.Context, event *models.OrderCreatedEvent) error {
+	userID, err := helpers.StringToUUID(event.UserID)
+	if err != nil {
+		return apperrors.Invalid("user_id", "uuid", "must be a valid UUID")
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(event.ProductIDs))
+	for _, idStr := range event.ProductIDs {
+		productID, err := helpers.StringToUUID(idStr)
+		if err != nil {
+			return apperrors.Invalid("product_ids", "uuid", "must contain valid UUIDs")
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	if err := s.reviewRepo.RecordVerifiedPurchases(ctx, userID, event.OrderID, productIDs); err != nil {
+		return fmt.Errorf("service: failed to record verified purchase for order %s: %w", event.OrderID, err)
+	}
+
+	logctx.From(ctx, s.log).WithFields(logrus.Fields{"order_id": event.OrderID, "user_id": userID, "products": len(productIDs)}).Info("Verified purchases recorded")
+	return nil
+}
+
+// ------- HELPERS -------
+
+// adjustRating applies a delta to the product's rating aggregates inside the review transaction,
+// so the average never has to be recomputed from all reviews on read.
+func (s *reviewServiceImpl) adjustRating(ctx context.Context, tx *sql.Tx, productID uuid.UUID, countDelta, sumDelta int) error {
+	if _, err := s.productRepo.AdjustProductRating(ctx, tx, db.AdjustProductRatingParams{
+		ProductID:  productID,
+		CountDelta: int32(countDelta),
+		SumDelta:   int32(sumDelta),
+	}); err != nil {
+		return fmt.Errorf("service: failed to update product rating: %w", err)
+	}
+
+	return nil
+}
+
+func (s *reviewServiceImpl) invalidateProduct(ctx context.Context, productID uuid.UUID) {
+	if err := s.productSvc.InvalidateProductCache(ctx, productID); err != nil {
+		logctx.From(ctx, s.log).WithError(err).Error("Failed to clear product cache")
+	}
+}
+
+func toDomainReview(dbReview *db.ProductReview) *entities.Review {
+	review := &entities.Review{
+		ID:               dbReview.ID,
+		ProductID:        dbReview.ProductID,
+		UserID:           dbReview.UserID,
+		Rating:           int(dbReview.Rating),
+		Body:             dbReview.Body.String,
+		VerifiedPurchase: dbReview.VerifiedPurchase,
+		SellerReply:      dbReview.SellerReply.String,
+		ReportCount:      int(dbReview.ReportCount),
+		Hidden:           dbReview.Hidden,
+		CreatedAt:        dbReview.CreatedAt,
+		UpdatedAt:        dbReview.UpdatedAt,
+	}
+
+	if dbReview.SellerRepliedAt.Valid {
+		repliedAt := dbReview.SellerRepliedAt.Time
+		review.SellerRepliedAt = &repliedAt
+	}
+
+	return review
+}
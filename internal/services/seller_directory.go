@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/cache"
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/grpc/account"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/logctx"
+)
+
+type sellerDirectoryImpl struct {
+	profileCache  *cache.Cache[entities.SellerProfile]
+	accountClient *account.AccountClient
+	log           *logrus.Logger
+}
+
+// NewSellerDirectory caches seller profiles for ttl so storefront and cart traffic does not turn
+// into one account RPC per request.
+func NewSellerDirectory(cacheStore *cache.Store, accountClient *account.AccountClient, ttl time.Duration, log *logrus.Logger) gateways.SellerDirectory {
+	return &sellerDirectoryImpl{
+		profileCache: cache.New[entities.SellerProfile](cacheStore, cache.Options{
+			Namespace: "seller_profile",
+			TTL:       ttl,
+			NotFound:  apperrors.ErrSellerNotFound,
+		}),
+		accountClient: accountClient,
+		log:           log,
+	}
+}
+
+// GetSellerProfile returns ErrSellerNotFound for unknown sellers. Any other failure yields a
+// profile without a name.
+func (s *sellerDirectoryImpl) GetSellerProfile(ctx context.Context, sellerID uuid.UUID) (*entities.SellerProfile, error) {
+	profile, err := s.profileCache.GetOrLoad(ctx, sellerID.String(), func(ctx context.Context) (entities.SellerProfile, error) {
+		user, err := s.accountClient.GetUser(ctx, sellerID.String())
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return entities.SellerProfile{}, apperrors.ErrSellerNotFound
+			}
+			return entities.SellerProfile{}, err
+		}
+
+		return entities.SellerProfile{ID: sellerID, Name: user.GetName()}, nil
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrSellerNotFound) {
+			return nil, err
+		}
+
+		// Load errors are never cached, so the next request retries the account service.
+		logctx.From(ctx, s.log).WithField("seller_id", sellerID).WithError(err).Warn("Failed to fetch seller profile from account service")
+		return &entities.SellerProfile{ID: sellerID}, nil
+	}
+
+	return &profile, nil
+}
+
+// GetSellerProfiles returns the profiles it could resolve. Sellers missing from the result are
+// unknown to the account service or could not be fetched.
+func (s *sellerDirectoryImpl) GetSellerProfiles(ctx context.Context, sellerIDs []uuid.UUID) map[uuid.UUID]entities.SellerProfile {
+	profiles := make(map[uuid.UUID]entities.SellerProfile, len(sellerIDs))
+	if len(sellerIDs) == 0 {
+		return profiles
+	}
+
+	keys := make([]string, 0, len(sellerIDs))
+	for _, id := range sellerIDs {
+		keys = append(keys, id.String())
+	}
+
+	found, err := s.profileCache.GetOrLoadMany(ctx, keys, func(ctx context.Context, missing []string) (map[string]entities.SellerProfile, error) {
+		res, err := s.accountClient.GetUsers(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded := make(map[string]entities.SellerProfile, len(res.GetUsers()))
+		for _, user := range res.GetUsers() {
+			id, err := uuid.Parse(user.GetId())
+			if err != nil {
+				continue
+			}
+			loaded[user.GetId()] = entities.SellerProfile{ID: id, Name: user.GetName()}
+		}
+
+		return loaded, nil
+	})
+	if err != nil {
+		logctx.From(ctx, s.log).WithField("sellers", len(sellerIDs)).WithError(err).Warn("Failed to fetch seller profiles from account service")
+		return profiles
+	}
+
+	for _, profile := range found {
+		profiles[profile.ID] = profile
+	}
+
+	return profiles
+}
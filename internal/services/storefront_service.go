@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/entities"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/gateways"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/repositories"
+)
+
+const (
+	SortByNewest    = "newest"
+	SortByPriceAsc  = "price_asc"
+	SortByPriceDesc = "price_desc"
+)
+
+type StorefrontService interface {
+	GetStorefront(ctx context.Context, sellerID uuid.UUID) (*entities.Storefront, error)
+	GetSellerProducts(ctx context.Context, sellerID uuid.UUID, sortBy string, page, perPage int) ([]entities.Product, int, error)
+}
+
+type storefrontServiceImpl struct {
+	productRepo      repositories.ProductRepository
+	sellers          gateways.SellerDirectory
+	featuredLimit    int
+	newArrivalsLimit int
+	log              *logrus.Logger
+}
+
+func NewStorefrontService(
+	productRepo repositories.ProductRepository,
+	sellers gateways.SellerDirectory,
+	featuredLimit int,
+	newArrivalsLimit int,
+	log *logrus.Logger,
+) StorefrontService {
+	return &storefrontServiceImpl{
+		productRepo:      productRepo,
+		sellers:          sellers,
+		featuredLimit:    featuredLimit,
+		newArrivalsLimit: newArrivalsLimit,
+		log:              log,
+	}
+}
+
+func (s *storefrontServiceImpl) GetStorefront(ctx context.Context, sellerID uuid.UUID) (*entities.Storefront, error) {
+	// The storefront is still useful without the display name, so the directory degrades instead of failing.
+	profile, err := s.sellers.GetSellerProfile(ctx, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.productRepo.GetSellerProductStats(ctx, sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve stats for seller %s: %w", sellerID, err)
+	}
+
+	dbCategories, err := s.productRepo.GetSellerCategories(ctx, sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve categories for seller %s: %w", sellerID, err)
+	}
+
+	categories := make([]entities.SellerCategory, 0, len(dbCategories))
+	for _, c := range dbCategories {
+		categories = append(categories, entities.SellerCategory{
+			Type:         c.Type,
+			ProductCount: int(c.ProductCount),
+		})
+	}
+
+	topRated, err := s.getSellerProductsPage(ctx, sellerID, SortByRating, s.featuredLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only products that actually have ratings are worth featuring.
+	featured := make([]entities.Product, 0, len(topRated))
+	for _, p := range topRated {
+		if p.RatingCount > 0 {
+			featured = append(featured, p)
+		}
+	}
+
+	newArrivals, err := s.getSellerProductsPage(ctx, sellerID, SortByNewest, s.newArrivalsLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	storefront := &entities.Storefront{
+		Seller:       *profile,
+		ProductCount: int(stats.ProductCount),
+		RatingCount:  int(stats.RatingCount),
+		Categories:   categories,
+		Featured:     featured,
+		NewArrivals:  newArrivals,
+	}
+
+	if stats.RatingCount > 0 {
+		storefront.RatingAverage = float64(stats.RatingSum) / float64(stats.RatingCount)
+	}
+
+	return storefront, nil
+}
+
+func (s *storefrontServiceImpl) GetSellerProducts(ctx context.Context, sellerID uuid.UUID, sortBy string, page, perPage int) ([]entities.Product, int, error) {
+	products, err := s.getSellerProductsPage(ctx, sellerID, sortBy, perPage, int(pageOffset(page, perPage)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stats, err := s.productRepo.GetSellerProductStats(ctx, sellerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service: failed to count products for seller %s: %w", sellerID, err)
+	}
+
+	return products, int(stats.ProductCount), nil
+}
+
+// ------- HELPERS -------
+
+func (s *storefrontServiceImpl) getSellerProductsPage(ctx context.Context, sellerID uuid.UUID, sortBy string, limit, offset int) ([]entities.Product, error) {
+	dbProducts, err := s.productRepo.GetProductsBySellerIDPaged(ctx, &db.GetProductsBySellerIDPagedParams{
+		SellerID:   sellerID,
+		Sort:       sortBy,
+		PageLimit:  int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service: failed to retrieve products for seller %s: %w", sellerID, err)
+	}
+
+	return toDomainProducts(dbProducts), nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	apperrors "github.com/RehanAthallahAzhar/tokohobby-catalog/internal/pkg/errors"
+)
+
+// validationError turns validator failures into a payload error with one detail per field. The
+// message is only a fallback for rules the message catalog does not know.
+func validationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperrors.ErrInvalidRequestPayload.Wrap(err)
+	}
+
+	details := make([]apperrors.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		details = append(details, apperrors.FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: fmt.Sprintf("failed on the '%s' rule", fieldErr.Tag()),
+		})
+	}
+
+	return apperrors.ErrInvalidRequestPayload.WithDetails(details...)
+}
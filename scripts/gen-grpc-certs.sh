@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+# Generates a throwaway CA, a server certificate for localhost and client certificates for
+# local mTLS testing of the gRPC server. The client certificate CN is the service identity
+# matched against GRPC_METHOD_ALLOWLIST.
+#
+# Usage: scripts/gen-grpc-certs.sh [out_dir] [client_service...]
+#   defaults: out_dir=certs, client_service=orders
+set -euo pipefail
+
+OUT_DIR="${1:-certs}"
+shift || true
+CLIENTS=("${@:-orders}")
+DAYS=365
+
+mkdir -p "$OUT_DIR"
+cd "$OUT_DIR"
+
+openssl req -x509 -newkey rsa:2048 -nodes -days "$DAYS" \
+  -keyout ca.key -out ca.crt -subj "/CN=tokohobby-local-ca" >/dev/null 2>&1
+
+openssl req -newkey rsa:2048 -nodes -keyout server.key -out server.csr \
+  -subj "/CN=catalog" >/dev/null 2>&1
+openssl x509 -req -in server.csr -CA ca.crt -CAkey ca.key -CAcreateserial -days "$DAYS" \
+  -extfile <(printf "subjectAltName=DNS:localhost,DNS:catalog,IP:127.0.0.1\nextendedKeyUsage=serverAuth") \
+  -out server.crt >/dev/null 2>&1
+
+for client in "${CLIENTS[@]}"; do
+  openssl req -newkey rsa:2048 -nodes -keyout "$client.key" -out "$client.csr" \
+    -subj "/CN=$client" >/dev/null 2>&1
+  openssl x509 -req -in "$client.csr" -CA ca.crt -CAkey ca.key -CAcreateserial -days "$DAYS" \
+    -extfile <(printf "extendedKeyUsage=clientAuth") \
+    -out "$client.crt" >/dev/null 2>&1
+done
+
+rm -f ./*.csr ca.srl
+
+cat <<MSG
+Certificates written to $OUT_DIR. Point the server at them with:
+  SERVER_INSECURE=false
+  SERVER_TLS_CERT_FILE=$OUT_DIR/server.crt
+  SERVER_TLS_KEY_FILE=$OUT_DIR/server.key
+  SERVER_TLS_CLIENT_CA_FILE=$OUT_DIR/ca.crt
+MSG
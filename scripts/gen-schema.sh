@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+# Regenerates db/schema/schema.sql, the schema sqlc compiles queries against, from the up
+# migrations in order. Run it after adding a migration, then `sqlc generate`.
+#
+# Usage: scripts/gen-schema.sh
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+{
+  echo "-- Code generated by scripts/gen-schema.sh from db/migrations. DO NOT EDIT."
+  for migration in db/migrations/*.up.sql; do
+    printf '\n-- %s\n' "$(basename "$migration")"
+    cat "$migration"
+  done
+} > db/schema/schema.sql
@@ -0,0 +1,205 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dhui/dktest"
+	"github.com/docker/docker/client"
+	"github.com/golang-migrate/migrate/v4"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/db"
+	"github.com/RehanAthallahAzhar/tokohobby-catalog/internal/configs"
+)
+
+const (
+	migrationsDir  = "../db/migrations"
+	schemaFile     = "../db/schema/schema.sql"
+	postgresImage  = "postgres:16-alpine"
+	postgresPass   = "postgres"
+	postgresDBName = "catalog"
+)
+
+// TestSchemaMatchesMigrations keeps the schema sqlc compiles against in step with the migrations.
+func TestSchemaMatchesMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want strings.Builder
+	want.WriteString("-- Code generated by scripts/gen-schema.sh from db/migrations. DO NOT EDIT.\n")
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&want, "\n-- %s\n%s", filepath.Base(file), raw)
+	}
+
+	got, err := os.ReadFile(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want.String() {
+		t.Fatalf("%s is out of date, run scripts/gen-schema.sh", schemaFile)
+	}
+}
+
+// TestMigrationsUpDownUp applies every migration to a throwaway Postgres, rolls them all back and
+// applies them again, so each down file must undo its up file completely.
+func TestMigrationsUpDownUp(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a Postgres container")
+	}
+	requireDocker(t)
+
+	opts := dktest.Options{
+		Env:          map[string]string{"POSTGRES_PASSWORD": postgresPass, "POSTGRES_DB": postgresDBName},
+		PortRequired: true,
+		ReadyFunc: func(ctx context.Context, c dktest.ContainerInfo) bool {
+			conn, err := sql.Open("postgres", migrationURL(c))
+			if err != nil {
+				return false
+			}
+			defer conn.Close()
+			return conn.PingContext(ctx) == nil
+		},
+	}
+
+	dktest.Run(t, postgresImage, opts, func(t *testing.T, c dktest.ContainerInfo) {
+		m, err := migrate.New("file://"+migrationsDir, migrationURL(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m.Close()
+
+		conn, err := sql.Open("postgres", migrationURL(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		latest := latestVersion(t)
+
+		if err := m.Up(); err != nil {
+			t.Fatalf("up: %v", err)
+		}
+		assertVersion(t, m, latest)
+
+		if err := m.Down(); err != nil {
+			t.Fatalf("down: %v", err)
+		}
+		if tables := userTables(t, conn); len(tables) > 0 {
+			t.Fatalf("down left tables behind: %v", tables)
+		}
+
+		if err := m.Up(); err != nil {
+			t.Fatalf("up after down: %v", err)
+		}
+		assertVersion(t, m, latest)
+
+		_, err = conn.Exec(`INSERT INTO products (id, seller_id, name, price, stock, created_at, updated_at)
+			VALUES (gen_random_uuid(), gen_random_uuid(), 'Negative stock', 1000, -1, NOW(), NOW())`)
+		if err == nil {
+			t.Fatal("products accepted negative stock")
+		}
+	})
+}
+
+// ------- HELPERS -------
+
+func requireDocker(t *testing.T) {
+	t.Helper()
+
+	dc, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		t.Skipf("docker unavailable: %v", err)
+	}
+	defer dc.Close()
+
+	if _, err := dc.Ping(context.Background()); err != nil {
+		t.Skipf("docker unavailable: %v", err)
+	}
+}
+
+func migrationURL(c dktest.ContainerInfo) string {
+	host, port, err := c.FirstPort()
+	if err != nil {
+		return ""
+	}
+	portNum, _ := strconv.Atoi(port)
+
+	return db.MigrationURL(&configs.DatabaseConfig{
+		Host:     host,
+		User:     "postgres",
+		Password: postgresPass,
+		Name:     postgresDBName,
+		Port:     portNum,
+		SslMode:  "disable",
+		TimeZone: "Asia/Jakarta",
+	})
+}
+
+func latestVersion(t *testing.T) uint {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+
+	prefix, _, _ := strings.Cut(filepath.Base(files[len(files)-1]), "_")
+	version, err := strconv.ParseUint(prefix, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uint(version)
+}
+
+func assertVersion(t *testing.T, m *migrate.Migrate, want uint) {
+	t.Helper()
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		t.Fatal(err)
+	}
+	if version != want || dirty {
+		t.Fatalf("schema at version %d (dirty %t), want %d", version, dirty, want)
+	}
+}
+
+func userTables(t *testing.T, conn *sql.DB) []string {
+	t.Helper()
+
+	rows, err := conn.Query(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name <> 'schema_migrations'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return tables
+}